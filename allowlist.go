@@ -0,0 +1,61 @@
+package nft
+
+import "errors"
+
+// ErrNotAllowlisted is returned when an address that is not on the allowlist tries to mint.
+var ErrNotAllowlisted = errors.New("address is not allowlisted")
+
+// AllowlistRoot returns the hex-encoded Merkle root of the allowlist, or "" if allowlist
+// minting is closed.
+func (c *DefaultContract) AllowlistRoot() (string, error) {
+	if c.AllowlistMerkleRoot == "" && !c.allowlistRootLoaded {
+		if err := c.fetchAllowlistRoot(); err != nil {
+			return "", err
+		}
+	}
+	return c.AllowlistMerkleRoot, nil
+}
+
+// SetAllowlistRoot sets the hex-encoded Merkle root of the allowlist, as computed by
+// MerkleTree.Root. Setting "" closes allowlist minting. Only holders of MinterRole may set it.
+func (c *DefaultContract) SetAllowlistRoot(root string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	c.AllowlistMerkleRoot = root
+	c.allowlistRootLoaded = true
+	return nil
+}
+
+// AllowlistMint mints a new token to the caller, who does not need MinterRole but must prove
+// with a proof from MerkleTree.Proof that they are on the allowlist. The max supply and the
+// per-address mint limit apply as usual.
+func (c *DefaultContract) AllowlistMint(tokenID string, proof []string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if c.caller == "" {
+		return ErrUnauthorized
+	}
+	root, err := c.AllowlistRoot()
+	if err != nil {
+		return err
+	}
+	if !VerifyMerkleProof(root, c.caller, proof) {
+		return ErrNotAllowlisted
+	}
+	return c.mint(c.caller, tokenID)
+}
+
+func (c *DefaultContract) fetchAllowlistRoot() error {
+	resp, err := c.GetDragonObject("allowlistRoot")
+	if err != nil {
+		return err
+	}
+	c.AllowlistMerkleRoot = string(resp)
+	c.allowlistRootLoaded = true
+	return nil
+}
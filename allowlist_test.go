@@ -0,0 +1,148 @@
+package nft
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	allowlistRootTests = map[string]struct {
+		DCResponse    *dcResp
+		ExpectedRoot  string
+		ExpectedError error
+	}{
+		"fetch root": {
+			DCResponse: &dcResp{
+				Response: "abcd",
+			},
+			ExpectedRoot: "abcd",
+		},
+		"not in heap": {
+			DCResponse: &dcResp{},
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			ExpectedError: errFailed,
+		},
+	}
+
+	allowlistMintTests = map[string]struct {
+		Caller        string
+		ProofFor      string
+		TokenID       string
+		ClosedList    bool
+		ExpectedOwner string
+		ExpectedError error
+	}{
+		"allowlisted": {
+			Caller:        "alice",
+			ProofFor:      "alice",
+			TokenID:       "tokenID4",
+			ExpectedOwner: "alice",
+		},
+		"proof of another address": {
+			Caller:        "mallory",
+			ProofFor:      "alice",
+			TokenID:       "tokenID4",
+			ExpectedError: ErrNotAllowlisted,
+		},
+		"no caller": {
+			ProofFor:      "alice",
+			TokenID:       "tokenID4",
+			ExpectedError: ErrUnauthorized,
+		},
+		"allowlist closed": {
+			Caller:        "alice",
+			ProofFor:      "alice",
+			TokenID:       "tokenID4",
+			ClosedList:    true,
+			ExpectedError: ErrNotAllowlisted,
+		},
+		"token exists": {
+			Caller:        "bob",
+			ProofFor:      "bob",
+			TokenID:       "tokenID",
+			ExpectedOwner: "owner",
+			ExpectedError: ErrAlreadyExists,
+		},
+	}
+)
+
+func TestDefaultContract_AllowlistRoot(t *testing.T) {
+	for name, test := range allowlistRootTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			var ret *dragonchain.Response
+			if test.DCResponse.Error == nil {
+				ret = &dragonchain.Response{
+					OK:       true,
+					Status:   http.StatusOK,
+					Response: []byte(test.DCResponse.Response),
+				}
+			}
+			mockClient.On("GetSmartContractObject", "allowlistRoot", "").Once().Return(ret, test.DCResponse.Error)
+			root, err := contract.AllowlistRoot()
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedRoot, root)
+		})
+	}
+}
+
+func TestDefaultContract_SetAllowlistRoot(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.ContractMinter = "minter"
+	contract.SetCaller("owner")
+	assert.Equal(t, ErrUnauthorized, contract.SetAllowlistRoot("abcd"))
+
+	contract.SetCaller("minter")
+	assert.NoError(t, contract.SetAllowlistRoot("abcd"))
+	assert.JSONEq(t, `"abcd"`, string(heapOutput(t, contract)["allowlistRoot"]))
+	// Closing the allowlist must overwrite the root on the heap.
+	assert.NoError(t, contract.SetAllowlistRoot(""))
+	assert.JSONEq(t, `""`, string(heapOutput(t, contract)["allowlistRoot"]))
+}
+
+func TestDefaultContract_AllowlistMint(t *testing.T) {
+	for name, test := range allowlistMintTests {
+		t.Run(name, func(t *testing.T) {
+			tree := NewMerkleTree([]string{"alice", "bob", "carol"})
+			contract := newBatchTestContract()
+			if !test.ClosedList {
+				assert.NoError(t, contract.SetAllowlistRoot(tree.Root()))
+			}
+			proof, err := tree.Proof(test.ProofFor)
+			assert.NoError(t, err)
+
+			contract.SetCaller(test.Caller)
+			assert.Equal(t, test.ExpectedError, contract.AllowlistMint(test.TokenID, proof))
+			owner, _ := contract.OwnerOf(test.TokenID)
+			assert.Equal(t, test.ExpectedOwner, owner)
+		})
+	}
+}
+
+func TestDefaultContract_AllowlistMintLimit(t *testing.T) {
+	tree := NewMerkleTree([]string{"alice", "bob"})
+	contract := newBatchTestContract()
+	contract.ContractAdmin = "admin"
+	contract.SetCaller("admin")
+	assert.NoError(t, contract.SetAllowlistRoot(tree.Root()))
+	assert.NoError(t, contract.SetMintLimit(1))
+	proof, err := tree.Proof("alice")
+	assert.NoError(t, err)
+
+	contract.SetCaller("alice")
+	assert.NoError(t, contract.AllowlistMint("tokenID4", proof))
+	assert.Equal(t, ErrMintLimitExceeded, contract.AllowlistMint("tokenID5", proof))
+
+	contract.SetCaller("owner")
+	assert.Equal(t, ErrUnauthorized, contract.SetAllowlistRoot(""))
+}
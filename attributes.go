@@ -0,0 +1,386 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// ErrInvalidAttribute is returned when an Attribute's value does not match its type.
+var ErrInvalidAttribute = errors.New("attribute value does not match its type")
+
+// AttributeType is the type of the value held by an Attribute.
+type AttributeType string
+
+// The supported attribute types.
+const (
+	AttributeString AttributeType = "string"
+	AttributeInt    AttributeType = "int"
+	AttributeBigInt AttributeType = "bigint"
+	AttributeBool   AttributeType = "bool"
+)
+
+// Attribute is a typed value attached to a token, such as the rarity or level of a game item.
+// The value is stored in its canonical string form so that equal values always index the same way.
+type Attribute struct {
+	Type  AttributeType `json:"type"`
+	Value string        `json:"value"`
+}
+
+// StringAttribute returns a string Attribute.
+func StringAttribute(s string) Attribute {
+	return Attribute{Type: AttributeString, Value: s}
+}
+
+// IntAttribute returns an integer Attribute.
+func IntAttribute(i int64) Attribute {
+	return Attribute{Type: AttributeInt, Value: strconv.FormatInt(i, 10)}
+}
+
+// BigIntAttribute returns a big-number Attribute.
+func BigIntAttribute(i *big.Int) Attribute {
+	return Attribute{Type: AttributeBigInt, Value: i.String()}
+}
+
+// BoolAttribute returns a boolean Attribute.
+func BoolAttribute(b bool) Attribute {
+	return Attribute{Type: AttributeBool, Value: strconv.FormatBool(b)}
+}
+
+// Int returns the value of an integer Attribute.
+func (a Attribute) Int() (int64, error) {
+	if a.Type != AttributeInt {
+		return 0, ErrInvalidAttribute
+	}
+	i, err := strconv.ParseInt(a.Value, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAttribute
+	}
+	return i, nil
+}
+
+// BigInt returns the value of a big-number Attribute.
+func (a Attribute) BigInt() (*big.Int, error) {
+	if a.Type != AttributeBigInt {
+		return BigZero, ErrInvalidAttribute
+	}
+	i, err := BigIntString(a.Value)
+	if err != nil {
+		return BigZero, ErrInvalidAttribute
+	}
+	return i, nil
+}
+
+// Bool returns the value of a boolean Attribute.
+func (a Attribute) Bool() (bool, error) {
+	if a.Type != AttributeBool {
+		return false, ErrInvalidAttribute
+	}
+	b, err := strconv.ParseBool(a.Value)
+	if err != nil {
+		return false, ErrInvalidAttribute
+	}
+	return b, nil
+}
+
+// canonical returns the Attribute with its value in canonical form, or ErrInvalidAttribute if
+// the value does not match the type.
+func (a Attribute) canonical() (Attribute, error) {
+	switch a.Type {
+	case AttributeString:
+		return a, nil
+	case AttributeInt:
+		i, err := a.Int()
+		if err != nil {
+			return a, err
+		}
+		return IntAttribute(i), nil
+	case AttributeBigInt:
+		i, err := a.BigInt()
+		if err != nil {
+			return a, err
+		}
+		return BigIntAttribute(i), nil
+	case AttributeBool:
+		b, err := a.Bool()
+		if err != nil {
+			return a, err
+		}
+		return BoolAttribute(b), nil
+	}
+	return a, ErrInvalidAttribute
+}
+
+// indexKey is the key under which tokens with this Attribute are indexed. The type is part of
+// the key so that, for example, the string "1" and the integer 1 are not confused.
+func (a Attribute) indexKey() string {
+	return string(a.Type) + ":" + a.Value
+}
+
+// MintWithAttributes mints a new token like Mint and sets its initial attributes. Nothing is
+// minted if any of the attributes is invalid.
+func (c *DefaultContract) MintWithAttributes(to, tokenID string, attrs map[string]Attribute) error {
+	canonical := make(map[string]Attribute, len(attrs))
+	for name, attr := range attrs {
+		attr, err := attr.canonical()
+		if err != nil {
+			return err
+		}
+		canonical[name] = attr
+	}
+	if err := c.loadAttributes(); err != nil {
+		return err
+	}
+	if err := c.Mint(to, tokenID); err != nil {
+		return err
+	}
+	for name, attr := range canonical {
+		c.setAttribute(tokenID, name, attr)
+	}
+	return nil
+}
+
+// Attributes returns every attribute of a token.
+func (c *DefaultContract) Attributes(tokenID string) (map[string]Attribute, error) {
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return nil, err
+	}
+	if c.TokenAttributes == nil {
+		if err := c.fetchTokenAttributes(); err != nil {
+			return nil, err
+		}
+	}
+	attrs := make(map[string]Attribute, len(c.TokenAttributes[tokenID]))
+	for name, attr := range c.TokenAttributes[tokenID] {
+		attrs[name] = attr
+	}
+	return attrs, nil
+}
+
+// AttributeOf returns a single attribute of a token. ErrNoExist is returned if the token
+// does not have the attribute.
+func (c *DefaultContract) AttributeOf(tokenID, name string) (Attribute, error) {
+	attrs, err := c.Attributes(tokenID)
+	if err != nil {
+		return Attribute{}, err
+	}
+	attr, ok := attrs[name]
+	if !ok {
+		return Attribute{}, ErrNoExist
+	}
+	return attr, nil
+}
+
+// SetAttribute sets an attribute of a token, replacing any previous value. Only holders of
+// MinterRole and authorized attribute updaters may change attributes.
+func (c *DefaultContract) SetAttribute(tokenID, name string, value Attribute) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	value, err := value.canonical()
+	if err != nil {
+		return err
+	}
+	if err := c.authorizeAttributeUpdate(); err != nil {
+		return err
+	}
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return err
+	}
+	if err := c.loadAttributes(); err != nil {
+		return err
+	}
+	c.setAttribute(tokenID, name, value)
+	return nil
+}
+
+// RemoveAttribute removes an attribute from a token. Only holders of MinterRole and authorized
+// attribute updaters may change attributes.
+func (c *DefaultContract) RemoveAttribute(tokenID, name string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeAttributeUpdate(); err != nil {
+		return err
+	}
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return err
+	}
+	if err := c.loadAttributes(); err != nil {
+		return err
+	}
+	c.removeAttribute(tokenID, name)
+	return nil
+}
+
+// SetAttributeUpdater allows or disallows an address to change token attributes. Only
+// holders of MinterRole may manage attribute updaters.
+func (c *DefaultContract) SetAttributeUpdater(address string, allowed bool) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	if c.AttributeUpdaters == nil {
+		if err := c.fetchAttributeUpdaters(); err != nil {
+			return err
+		}
+	}
+	if allowed {
+		c.AttributeUpdaters[address] = true
+		return nil
+	}
+	delete(c.AttributeUpdaters, address)
+	return nil
+}
+
+// TokensWithAttribute returns the ids of every token whose attribute called name equals value,
+// for example every token whose "rarity" is StringAttribute("legendary"). The ids are sorted.
+func (c *DefaultContract) TokensWithAttribute(name string, value Attribute) ([]string, error) {
+	value, err := value.canonical()
+	if err != nil {
+		return nil, err
+	}
+	if c.AttributeIndex == nil {
+		if err := c.fetchAttributeIndex(); err != nil {
+			return nil, err
+		}
+	}
+	set := c.AttributeIndex[name][value.indexKey()]
+	tokens := make([]string, 0, len(set))
+	for tokenID := range set {
+		tokens = append(tokens, tokenID)
+	}
+	sort.Strings(tokens)
+	return tokens, nil
+}
+
+func (c *DefaultContract) authorizeAttributeUpdate() error {
+	if err := c.requireRole(MinterRole); err != ErrUnauthorized {
+		return err
+	}
+	if c.AttributeUpdaters == nil {
+		if err := c.fetchAttributeUpdaters(); err != nil {
+			return err
+		}
+	}
+	if c.caller == "" || !c.AttributeUpdaters[c.caller] {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// loadAttributes fetches the attribute store and its index from the heap if they have not
+// been loaded yet.
+func (c *DefaultContract) loadAttributes() error {
+	if c.TokenAttributes == nil {
+		if err := c.fetchTokenAttributes(); err != nil {
+			return err
+		}
+	}
+	if c.AttributeIndex == nil {
+		if err := c.fetchAttributeIndex(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAttribute stores a canonical attribute and keeps the index in sync. The attribute store
+// must be loaded.
+func (c *DefaultContract) setAttribute(tokenID, name string, value Attribute) {
+	c.removeAttribute(tokenID, name)
+	if c.TokenAttributes[tokenID] == nil {
+		c.TokenAttributes[tokenID] = make(map[string]Attribute)
+	}
+	c.TokenAttributes[tokenID][name] = value
+	if c.AttributeIndex[name] == nil {
+		c.AttributeIndex[name] = make(map[string]map[string]bool)
+	}
+	key := value.indexKey()
+	if c.AttributeIndex[name][key] == nil {
+		c.AttributeIndex[name][key] = make(map[string]bool)
+	}
+	c.AttributeIndex[name][key][tokenID] = true
+}
+
+// removeAttribute removes an attribute and its index entry. The attribute store must be loaded.
+func (c *DefaultContract) removeAttribute(tokenID, name string) {
+	old, ok := c.TokenAttributes[tokenID][name]
+	if !ok {
+		return
+	}
+	delete(c.TokenAttributes[tokenID], name)
+	if len(c.TokenAttributes[tokenID]) == 0 {
+		delete(c.TokenAttributes, tokenID)
+	}
+	key := old.indexKey()
+	delete(c.AttributeIndex[name][key], tokenID)
+	if len(c.AttributeIndex[name][key]) == 0 {
+		delete(c.AttributeIndex[name], key)
+	}
+	if len(c.AttributeIndex[name]) == 0 {
+		delete(c.AttributeIndex, name)
+	}
+}
+
+// removeAttributes removes every attribute of a token. The attribute store must be loaded.
+func (c *DefaultContract) removeAttributes(tokenID string) {
+	for name := range c.TokenAttributes[tokenID] {
+		c.removeAttribute(tokenID, name)
+	}
+}
+
+func (c *DefaultContract) fetchTokenAttributes() error {
+	resp, err := c.GetDragonObject("tokenAttributes")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenAttributes = make(map[string]map[string]Attribute)
+		return nil
+	}
+	var m map[string]map[string]Attribute
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenAttributes = m
+	return nil
+}
+
+func (c *DefaultContract) fetchAttributeIndex() error {
+	resp, err := c.GetDragonObject("attributeIndex")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.AttributeIndex = make(map[string]map[string]map[string]bool)
+		return nil
+	}
+	var m map[string]map[string]map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.AttributeIndex = m
+	return nil
+}
+
+func (c *DefaultContract) fetchAttributeUpdaters() error {
+	resp, err := c.GetDragonObject("attributeUpdaters")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.AttributeUpdaters = make(map[string]bool)
+		return nil
+	}
+	var m map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.AttributeUpdaters = m
+	return nil
+}
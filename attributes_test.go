@@ -0,0 +1,202 @@
+package nft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	attributeCanonicalTests = map[string]struct {
+		Attribute     Attribute
+		Expected      Attribute
+		ExpectedError error
+	}{
+		"string": {
+			Attribute: StringAttribute("legendary"),
+			Expected:  Attribute{Type: AttributeString, Value: "legendary"},
+		},
+		"int": {
+			Attribute: Attribute{Type: AttributeInt, Value: "+07"},
+			Expected:  Attribute{Type: AttributeInt, Value: "7"},
+		},
+		"bigint": {
+			Attribute: BigIntAttribute(new(big.Int).Lsh(bigOne, 100)),
+			Expected:  Attribute{Type: AttributeBigInt, Value: "1267650600228229401496703205376"},
+		},
+		"bool": {
+			Attribute: Attribute{Type: AttributeBool, Value: "1"},
+			Expected:  Attribute{Type: AttributeBool, Value: "true"},
+		},
+		"invalid int": {
+			Attribute:     Attribute{Type: AttributeInt, Value: "seven"},
+			ExpectedError: ErrInvalidAttribute,
+		},
+		"invalid bigint": {
+			Attribute:     Attribute{Type: AttributeBigInt, Value: "1.5"},
+			ExpectedError: ErrInvalidAttribute,
+		},
+		"invalid bool": {
+			Attribute:     Attribute{Type: AttributeBool, Value: "maybe"},
+			ExpectedError: ErrInvalidAttribute,
+		},
+		"unknown type": {
+			Attribute:     Attribute{Type: "float", Value: "1.5"},
+			ExpectedError: ErrInvalidAttribute,
+		},
+	}
+
+	setAttributeTests = map[string]struct {
+		Caller        string
+		TokenID       string
+		Name          string
+		Value         Attribute
+		ExpectedError error
+	}{
+		"set by minter": {
+			Caller:  "minter",
+			TokenID: "tokenID",
+			Name:    "level",
+			Value:   IntAttribute(2),
+		},
+		"set by updater": {
+			Caller:  "updater",
+			TokenID: "tokenID",
+			Name:    "level",
+			Value:   IntAttribute(2),
+		},
+		"set by owner": {
+			Caller:        "owner",
+			TokenID:       "tokenID",
+			Name:          "level",
+			Value:         IntAttribute(2),
+			ExpectedError: ErrUnauthorized,
+		},
+		"invalid value": {
+			Caller:        "minter",
+			TokenID:       "tokenID",
+			Name:          "level",
+			Value:         Attribute{Type: AttributeInt, Value: "two"},
+			ExpectedError: ErrInvalidAttribute,
+		},
+		"token no exist": {
+			Caller:        "minter",
+			TokenID:       "tokenID2",
+			Name:          "level",
+			Value:         IntAttribute(2),
+			ExpectedError: ErrNoExist,
+		},
+	}
+)
+
+func TestAttribute_Canonical(t *testing.T) {
+	for name, test := range attributeCanonicalTests {
+		t.Run(name, func(t *testing.T) {
+			attr, err := test.Attribute.canonical()
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError == nil {
+				assert.Equal(t, test.Expected, attr)
+			}
+		})
+	}
+}
+
+func TestDefaultContract_SetAttribute(t *testing.T) {
+	for name, test := range setAttributeTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner"}
+			contract.TokenAttributes = map[string]map[string]Attribute{"tokenID": {"level": IntAttribute(1)}}
+			contract.AttributeIndex = map[string]map[string]map[string]bool{"level": {"int:1": {"tokenID": true}}}
+			contract.AttributeUpdaters = map[string]bool{"updater": true}
+			contract.ContractMinter = "minter"
+			contract.SetCaller(test.Caller)
+			err := contract.SetAttribute(test.TokenID, test.Name, test.Value)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, IntAttribute(1), contract.TokenAttributes["tokenID"]["level"])
+				return
+			}
+			assert.Equal(t, test.Value, contract.TokenAttributes[test.TokenID][test.Name])
+			assert.Equal(t, map[string]map[string]map[string]bool{"level": {"int:2": {"tokenID": true}}}, contract.AttributeIndex)
+		})
+	}
+}
+
+func TestDefaultContract_TokensWithAttribute(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TotalTokens = "0"
+	contract.ContractMinter = "minter"
+	contract.SetCaller("minter")
+	assert.NoError(t, contract.MintWithAttributes("owner", "sword", map[string]Attribute{
+		"rarity": StringAttribute("legendary"),
+		"level":  IntAttribute(10),
+	}))
+	assert.NoError(t, contract.MintWithAttributes("owner", "shield", map[string]Attribute{
+		"rarity": StringAttribute("legendary"),
+		"level":  IntAttribute(3),
+	}))
+	assert.NoError(t, contract.MintWithAttributes("owner2", "potion", map[string]Attribute{
+		"rarity": StringAttribute("common"),
+		"level":  StringAttribute("10"),
+	}))
+
+	tokens, err := contract.TokensWithAttribute("rarity", StringAttribute("legendary"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shield", "sword"}, tokens)
+	tokens, err = contract.TokensWithAttribute("level", IntAttribute(10))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sword"}, tokens)
+
+	assert.NoError(t, contract.SetAttribute("shield", "rarity", StringAttribute("rare")))
+	tokens, err = contract.TokensWithAttribute("rarity", StringAttribute("legendary"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sword"}, tokens)
+
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Burn("sword"))
+	tokens, err = contract.TokensWithAttribute("rarity", StringAttribute("legendary"))
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+	assert.NotContains(t, contract.TokenAttributes, "sword")
+	assert.NotContains(t, contract.AttributeIndex["level"], "int:10")
+}
+
+func TestDefaultContract_MintWithInvalidAttributes(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TotalTokens = "0"
+	contract.ContractMinter = "minter"
+	contract.SetCaller("minter")
+	err := contract.MintWithAttributes("owner", "sword", map[string]Attribute{
+		"rarity": StringAttribute("legendary"),
+		"level":  {Type: AttributeInt, Value: "ten"},
+	})
+	assert.Equal(t, ErrInvalidAttribute, err)
+	_, err = contract.OwnerOf("sword")
+	assert.Equal(t, ErrNoExist, err)
+	assert.Empty(t, contract.TokenAttributes)
+}
+
+func TestDefaultContract_RemoveLastAttribute(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TokenOwners = map[string]string{"tokenID": "owner"}
+	contract.TokenAttributes = map[string]map[string]Attribute{"tokenID": {"level": IntAttribute(1)}}
+	contract.AttributeIndex = map[string]map[string]map[string]bool{"level": {"int:1": {"tokenID": true}}}
+	contract.AttributeUpdaters = map[string]bool{"updater": true}
+	contract.SetCaller("updater")
+	assert.NoError(t, contract.RemoveAttribute("tokenID", "level"))
+
+	// The emptied attribute store must overwrite the one on the heap.
+	output := heapOutput(t, contract)
+	assert.JSONEq(t, `{}`, string(output["tokenAttributes"]))
+	assert.JSONEq(t, `{}`, string(output["attributeIndex"]))
+}
@@ -0,0 +1,102 @@
+package nft
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrDuplicateToken is returned when the same token appears more than once in a batch.
+var ErrDuplicateToken = errors.New("token appears more than once in batch")
+
+// TokenMint describes a single mint in a batch.
+type TokenMint struct {
+	To      string `json:"to"`
+	TokenID string `json:"tokenId"`
+}
+
+// TokenTransfer describes a single transfer in a batch.
+type TokenTransfer struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	TokenID string `json:"tokenId"`
+}
+
+// MintBatch mints every token in mints. Every mint is validated before any of them is applied,
+// so either all of the tokens are minted or, if an error is returned, none of them are.
+func (c *DefaultContract) MintBatch(mints []TokenMint) error {
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	totalTokens, err := c.loadMint()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(mints))
+	for _, m := range mints {
+		if seen[m.TokenID] {
+			return ErrDuplicateToken
+		}
+		seen[m.TokenID] = true
+		if err := c.checkMint(m.TokenID); err != nil {
+			return err
+		}
+	}
+	if err := c.checkMintCaps(mints); err != nil {
+		return err
+	}
+	for _, m := range mints {
+		c.mintToken(m.To, m.TokenID)
+	}
+	c.TotalTokens = totalTokens.Add(totalTokens, big.NewInt(int64(len(mints)))).String()
+	return nil
+}
+
+// TransferBatch performs every transfer in transfers. Every transfer is validated against the
+// state before the batch, so a token may only appear once. Either all of the transfers are
+// applied or, if an error is returned, none of them are.
+func (c *DefaultContract) TransferBatch(transfers []TokenTransfer) error {
+	if err := c.loadTransfer(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(transfers))
+	for _, t := range transfers {
+		if seen[t.TokenID] {
+			return ErrDuplicateToken
+		}
+		seen[t.TokenID] = true
+		if err := c.checkTransfer(t.From, t.TokenID); err != nil {
+			return err
+		}
+	}
+	for _, t := range transfers {
+		c.transferToken(t.From, t.To, t.TokenID)
+	}
+	return nil
+}
+
+// BurnBatch burns every token in tokenIDs. Either all of the tokens are burned or, if an error
+// is returned, none of them are.
+func (c *DefaultContract) BurnBatch(tokenIDs []string) error {
+	totalTokens, err := c.loadBurn()
+	if err != nil {
+		return err
+	}
+	owners := make([]string, len(tokenIDs))
+	seen := make(map[string]bool, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		if seen[tokenID] {
+			return ErrDuplicateToken
+		}
+		seen[tokenID] = true
+		owner, err := c.checkBurn(tokenID)
+		if err != nil {
+			return err
+		}
+		owners[i] = owner
+	}
+	for i, tokenID := range tokenIDs {
+		c.burnToken(owners[i], tokenID)
+	}
+	c.TotalTokens = totalTokens.Sub(totalTokens, big.NewInt(int64(len(tokenIDs)))).String()
+	return nil
+}
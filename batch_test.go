@@ -0,0 +1,213 @@
+package nft
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mintBatchTests = map[string]struct {
+		Caller         string
+		Mints          []TokenMint
+		ExpectedSupply string
+		ExpectedError  error
+	}{
+		"tokens minted": {
+			Caller:         "minter",
+			Mints:          []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner3", TokenID: "tokenID5"}},
+			ExpectedSupply: "5",
+		},
+		"empty batch": {
+			Caller:         "minter",
+			ExpectedSupply: "3",
+		},
+		"token already exists": {
+			Caller:        "minter",
+			Mints:         []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner3", TokenID: "tokenID3"}},
+			ExpectedError: ErrAlreadyExists,
+		},
+		"duplicate token": {
+			Caller:        "minter",
+			Mints:         []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner3", TokenID: "tokenID4"}},
+			ExpectedError: ErrDuplicateToken,
+		},
+		"not minter": {
+			Caller:        "owner",
+			Mints:         []TokenMint{{To: "owner", TokenID: "tokenID4"}},
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
+	transferBatchTests = map[string]struct {
+		Caller        string
+		Transfers     []TokenTransfer
+		ExpectedError error
+	}{
+		"tokens transferred": {
+			Caller: "owner",
+			Transfers: []TokenTransfer{
+				{From: "owner", To: "owner2", TokenID: "tokenID"},
+				{From: "owner", To: "owner3", TokenID: "tokenID2"},
+			},
+		},
+		"one token not owned by caller": {
+			Caller: "owner",
+			Transfers: []TokenTransfer{
+				{From: "owner", To: "owner2", TokenID: "tokenID"},
+				{From: "owner2", To: "owner", TokenID: "tokenID3"},
+			},
+			ExpectedError: ErrUnauthorized,
+		},
+		"one token does not exist": {
+			Caller: "owner",
+			Transfers: []TokenTransfer{
+				{From: "owner", To: "owner2", TokenID: "tokenID"},
+				{From: "owner", To: "owner2", TokenID: "tokenID9"},
+			},
+			ExpectedError: ErrNoExist,
+		},
+		"duplicate token": {
+			Caller: "owner",
+			Transfers: []TokenTransfer{
+				{From: "owner", To: "owner2", TokenID: "tokenID"},
+				{From: "owner2", To: "owner3", TokenID: "tokenID"},
+			},
+			ExpectedError: ErrDuplicateToken,
+		},
+	}
+
+	burnBatchTests = map[string]struct {
+		Caller         string
+		TokenIDs       []string
+		ExpectedSupply string
+		ExpectedError  error
+	}{
+		"tokens burned": {
+			Caller:         "owner",
+			TokenIDs:       []string{"tokenID", "tokenID2"},
+			ExpectedSupply: "1",
+		},
+		"one token not owned by caller": {
+			Caller:        "owner",
+			TokenIDs:      []string{"tokenID", "tokenID3"},
+			ExpectedError: ErrUnauthorized,
+		},
+		"one token does not exist": {
+			Caller:        "owner",
+			TokenIDs:      []string{"tokenID", "tokenID9"},
+			ExpectedError: ErrNoExist,
+		},
+		"duplicate token": {
+			Caller:        "owner",
+			TokenIDs:      []string{"tokenID", "tokenID"},
+			ExpectedError: ErrDuplicateToken,
+		},
+	}
+)
+
+// newBatchTestContract returns a contract in which owner holds tokenID and tokenID2 and
+// owner2 holds tokenID3.
+func newBatchTestContract() *DefaultContract {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TotalTokens = "0"
+	contract.ContractMinter = "minter"
+	contract.SetCaller("minter")
+	contract.MintBatch([]TokenMint{
+		{To: "owner", TokenID: "tokenID"},
+		{To: "owner", TokenID: "tokenID2"},
+		{To: "owner2", TokenID: "tokenID3"},
+	})
+	contract.TokenURIs = map[string]string{"tokenID": "uri"}
+	contract.TokenApprovals = map[string]string{"tokenID2": "approved"}
+	return contract
+}
+
+// heapState returns the heap output of contract without its empty maps and lists. Loading
+// an empty object from the heap does not change the state of the contract, so they are
+// ignored when checking that a failed batch left the contract unchanged.
+func heapState(contract *DefaultContract) map[string]interface{} {
+	b, _ := json.Marshal(contract)
+	var state map[string]interface{}
+	json.Unmarshal(b, &state)
+	for key, value := range state {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 {
+				delete(state, key)
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				delete(state, key)
+			}
+		}
+	}
+	return state
+}
+
+func TestDefaultContract_MintBatch(t *testing.T) {
+	for name, test := range mintBatchTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			before := heapState(contract)
+			contract.SetCaller(test.Caller)
+			err := contract.MintBatch(test.Mints)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, before, heapState(contract))
+				return
+			}
+			assert.Equal(t, test.ExpectedSupply, contract.TotalTokens)
+			assert.Len(t, contract.TokenList, len(contract.TokenOwners))
+			for _, m := range test.Mints {
+				assert.Equal(t, m.To, contract.TokenOwners[m.TokenID])
+			}
+		})
+	}
+}
+
+func TestDefaultContract_TransferBatch(t *testing.T) {
+	for name, test := range transferBatchTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			before := heapState(contract)
+			contract.SetCaller(test.Caller)
+			err := contract.TransferBatch(test.Transfers)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, before, heapState(contract))
+				return
+			}
+			assert.Equal(t, "3", contract.TotalTokens)
+			for _, tr := range test.Transfers {
+				assert.Equal(t, tr.To, contract.TokenOwners[tr.TokenID])
+				assert.NotContains(t, contract.TokenApprovals, tr.TokenID)
+			}
+		})
+	}
+}
+
+func TestDefaultContract_BurnBatch(t *testing.T) {
+	for name, test := range burnBatchTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			before := heapState(contract)
+			contract.SetCaller(test.Caller)
+			err := contract.BurnBatch(test.TokenIDs)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, before, heapState(contract))
+				return
+			}
+			assert.Equal(t, test.ExpectedSupply, contract.TotalTokens)
+			assert.Len(t, contract.TokenList, len(contract.TokenOwners))
+			for _, tokenID := range test.TokenIDs {
+				assert.NotContains(t, contract.TokenOwners, tokenID)
+				assert.NotContains(t, contract.TokenURIs, tokenID)
+			}
+		})
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/dragonchain/dragonchain-sdk-go"
 )
@@ -23,6 +26,10 @@ var (
 	ErrAlreadyExists = errors.New("resource already exists")
 	// ErrInvalidBigIntString is returned when a String cannot be converted to a big.Int
 	ErrInvalidBigIntString = errors.New("big.Int invalid")
+	// ErrNotOwner is returned when an address acts on a token it does not own.
+	ErrNotOwner = errors.New("address does not own token")
+	// ErrUnauthorized is returned when the caller is not allowed to perform an operation.
+	ErrUnauthorized = errors.New("caller is not authorized")
 )
 
 // Client is a client for interacting with the DragonChain API.
@@ -41,6 +48,10 @@ type Contract interface {
 	Transfer(from, to, tokenID string) error
 	TotalSupply() (*big.Int, error)
 	TokensOwnedBy(owner string) ([]string, error)
+	Approve(owner, approved, tokenID string) error
+	GetApproved(tokenID string) (string, error)
+	SetApprovalForAll(owner, operator string, approved bool) error
+	IsApprovedForAll(owner, operator string) (bool, error)
 }
 
 // DefaultContract is a basic NFT smart contract implementation that is designed to work with
@@ -50,11 +61,74 @@ type DefaultContract struct {
 	OwnedTokens     map[string][]string `json:"ownedTokens,omitempty"`
 	OwnedTokenIndex map[string]uint64   `json:"ownedTokenIndex,omitempty"`
 	TotalTokens     string              `json:"totalTokens,omitempty"`
+	TokenApprovals  map[string]string   `json:"tokenApprovals,omitempty"`
+	// OperatorApprovals maps an owner to the set of operators allowed to manage all of
+	// the owner's tokens.
+	OperatorApprovals map[string]map[string]bool `json:"operatorApprovals,omitempty"`
+	// TokenList holds every token in existence in mint order, except that burned tokens
+	// are replaced by the last token in the list. TokenListIndex is the position of each
+	// token in TokenList.
+	TokenList      []string          `json:"allTokens,omitempty"`
+	TokenListIndex map[string]uint64 `json:"allTokensIndex,omitempty"`
+	// BaseTokenURI is prefixed to a token's id to build its metadata URI. TokenURIs holds
+	// per-token URIs that take precedence over it.
+	BaseTokenURI string            `json:"baseURI,omitempty"`
+	TokenURIs    map[string]string `json:"tokenURIs,omitempty"`
+	// TokenAttributes holds the named attributes of each token. AttributeIndex maps an
+	// attribute name and indexed value to the set of tokens carrying it, and
+	// AttributeUpdaters is the set of addresses besides the minter that may change attributes.
+	TokenAttributes   map[string]map[string]Attribute       `json:"tokenAttributes,omitempty"`
+	AttributeIndex    map[string]map[string]map[string]bool `json:"attributeIndex,omitempty"`
+	AttributeUpdaters map[string]bool                       `json:"attributeUpdaters,omitempty"`
+	// DefaultRoyalty applies to every token without an entry in TokenRoyalties.
+	DefaultRoyalty *Royalty           `json:"defaultRoyalty,omitempty"`
+	TokenRoyalties map[string]Royalty `json:"tokenRoyalties,omitempty"`
+	// MaxTokens caps MintedTokens, the number of tokens ever minted, which burning does not
+	// lower; a cap of "" or zero means the supply is not capped. AddressMintLimit caps the
+	// number of tokens minted to each address, which MintedCounts tracks. A nil
+	// AddressMintLimit has not been loaded yet and a limit of zero means there is no limit.
+	MaxTokens        string            `json:"maxSupply,omitempty"`
+	MintedTokens     string            `json:"mintedTokens,omitempty"`
+	AddressMintLimit *uint64           `json:"mintLimit,omitempty"`
+	MintedCounts     map[string]uint64 `json:"mintedCounts,omitempty"`
 
 	ContractName   string `json:"name"`
 	ContractSymbol string `json:"symbol"`
+	// ContractMinter implicitly holds MinterRole.
+	ContractMinter string `json:"minter,omitempty"`
+	// ContractAdmin implicitly holds every role.
+	ContractAdmin string `json:"admin,omitempty"`
+	// Soulbound makes every token in the collection soulbound.
+	Soulbound bool `json:"soulbound,omitempty"`
+	// Roles maps a role to the set of addresses that have been granted it.
+	Roles map[string]map[string]bool `json:"roles,omitempty"`
+	// VoucherSigners is the set of hex-encoded ed25519 public keys allowed to sign mint
+	// vouchers, and VoucherNonces is the set of nonces of redeemed vouchers.
+	VoucherSigners map[string]bool `json:"voucherSigners,omitempty"`
+	VoucherNonces  map[string]bool `json:"voucherNonces,omitempty"`
+	// AllowlistMerkleRoot is the hex-encoded Merkle root of the addresses allowed to mint
+	// with AllowlistMint.
+	AllowlistMerkleRoot string `json:"allowlistRoot,omitempty"`
+	// TokenUsers holds the rental of each rented token.
+	TokenUsers map[string]Rental `json:"tokenUsers,omitempty"`
+	// SoulboundTokens is the set of tokens minted with MintSoulbound.
+	SoulboundTokens map[string]bool `json:"soulboundTokens,omitempty"`
+	// TokenHistory holds the provenance of every token ever minted, oldest entry first.
+	// Entries are only ever appended, and are kept when a token is burned.
+	TokenHistory map[string][]Provenance `json:"tokenHistory,omitempty"`
+	// ContractPaused is nil until the paused flag has been loaded from the heap.
+	ContractPaused *bool `json:"paused,omitempty"`
 
-	client Client
+	client       Client
+	caller       string
+	invocationID string
+	timestamp    uint64
+	receivers    map[string]TokenReceiver
+	events       []Event
+
+	baseURILoaded       bool
+	maxTokensLoaded     bool
+	allowlistRootLoaded bool
 }
 
 // NewDefaultContract returns a DefaultContract that uses the provided DragonChain client.
@@ -66,6 +140,25 @@ func NewDefaultContract(name, symbol string, client Client) *DefaultContract {
 	}
 }
 
+// SetCaller sets the address that invoked the contract. Every operation that changes
+// the state of the contract is authorized against this address; if no caller is set,
+// those operations fail with ErrUnauthorized. The Runtime sets the caller to the invoker
+// of the transaction it runs.
+func (c *DefaultContract) SetCaller(address string) {
+	c.caller = address
+}
+
+// Caller returns the address that invoked the contract.
+func (c *DefaultContract) Caller() string {
+	return c.caller
+}
+
+// SetInvocationID sets the id of the transaction that invoked the contract. It is recorded in
+// the provenance of every token minted, transferred or burned during the invocation.
+func (c *DefaultContract) SetInvocationID(id string) {
+	c.invocationID = id
+}
+
 // Name returns the name of the Contract.
 func (c *DefaultContract) Name() string {
 	return c.ContractName
@@ -96,93 +189,58 @@ func (c *DefaultContract) OwnerOf(tokenID string) (string, error) {
 }
 
 // Mint mints a new token with the provided ID and assigns it to the "to" address.
+// Only holders of MinterRole may mint tokens. Minting fails with ErrMaxSupplyReached or
+// ErrMintLimitExceeded once the supply cap or the "to" address's mint limit is reached.
 func (c *DefaultContract) Mint(to, tokenID string) error {
-	if c.TokenOwners == nil {
-		if err := c.fetchTokenOwners(); err != nil {
-			return err
-		}
-	}
-	if c.OwnedTokens == nil {
-		if err := c.fetchOwnedTokens(); err != nil {
-			return err
-		}
-	}
-	if c.OwnedTokenIndex == nil {
-		if err := c.fetchOwnedTokenIndices(); err != nil {
-			return err
-		}
-	}
-	// If the token already exists, we don't want to remint it.
-	if _, ok := c.TokenOwners[tokenID]; ok {
-		return ErrAlreadyExists
+	if err := c.authorizeMint(); err != nil {
+		return err
 	}
-	totalTokens, err := c.TotalSupply()
+	return c.mint(to, tokenID)
+}
+
+// mint mints a new token without authorizing the caller.
+func (c *DefaultContract) mint(to, tokenID string) error {
+	totalTokens, err := c.loadMint()
 	if err != nil {
 		return err
 	}
-	// add token to "to" address
-	c.TokenOwners[tokenID] = to
-	balance, err := c.BalanceOf(to)
-	if err != nil && err != ErrNoExist {
+	if err := c.checkMint(tokenID); err != nil {
+		return err
+	}
+	if err := c.checkMintCaps([]TokenMint{{To: to, TokenID: tokenID}}); err != nil {
 		return err
 	}
-	c.OwnedTokens[to] = append(c.OwnedTokens[to], tokenID)
-	c.OwnedTokenIndex[tokenID] = balance
+	c.mintToken(to, tokenID)
 	c.TotalTokens = totalTokens.Add(totalTokens, bigOne).String()
 	return nil
 }
 
-// Burn destroys a token and removes it from its owner.
+// Burn destroys a token and removes it from its owner. The caller must own the token,
+// be approved for it, be an approved operator of its owner, or hold BurnerRole. Soulbound
+// tokens may only be burned by their owner or a holder of AdminRole.
 func (c *DefaultContract) Burn(tokenID string) error {
-	owner, err := c.OwnerOf(tokenID)
+	totalTokens, err := c.loadBurn()
+	if err != nil {
+		return err
+	}
+	owner, err := c.checkBurn(tokenID)
 	if err != nil {
 		return err
 	}
-	return c.removeToken(owner, tokenID)
+	c.burnToken(owner, tokenID)
+	c.TotalTokens = totalTokens.Sub(totalTokens, bigOne).String()
+	return nil
 }
 
 // Transfer transfers the token with the given id from the "from" address to the "to" address.
+// The "from" address must own the token, and the caller must either be the "from" address,
+// be approved for the token, or be an approved operator of the "from" address. Soulbound
+// tokens cannot be transferred.
 func (c *DefaultContract) Transfer(from, to, tokenID string) error {
-	if c.TokenOwners == nil {
-		if err := c.fetchTokenOwners(); err != nil {
-			return err
-		}
-	}
-	if c.OwnedTokens == nil {
-		if err := c.fetchOwnedTokens(); err != nil {
-			return err
-		}
-	}
-	if c.OwnedTokenIndex == nil {
-		if err := c.fetchOwnedTokenIndices(); err != nil {
-			return err
-		}
-	}
-	balance, err := c.BalanceOf(to)
-	if err != nil && err != ErrNoExist {
+	if err := c.prepareTransfer(from, tokenID); err != nil {
 		return err
 	}
-	// Make sure the token is actually owned by the from address.
-	tokenIndex, ok := c.OwnedTokenIndex[tokenID]
-	if !ok {
-		return ErrNoExist
-	}
-	// Make sure the from address has tokens to begin with.
-	if _, ok := c.OwnedTokens[from]; !ok {
-		return ErrNoExist
-	}
-	// remove token from "from" address
-	delete(c.TokenOwners, tokenID)
-	c.OwnedTokens[from] = append(c.OwnedTokens[from][:tokenIndex], c.OwnedTokens[from][tokenIndex+1:]...)
-	if len(c.OwnedTokens[from]) == 0 {
-		delete(c.OwnedTokens, from)
-	}
-	delete(c.OwnedTokenIndex, tokenID)
-
-	// add token to "to" address
-	c.TokenOwners[tokenID] = to
-	c.OwnedTokens[to] = append(c.OwnedTokens[to], tokenID)
-	c.OwnedTokenIndex[tokenID] = balance
+	c.transferToken(from, to, tokenID)
 	return nil
 }
 
@@ -196,7 +254,7 @@ func (c *DefaultContract) TotalSupply() (*big.Int, error) {
 		return BigZero, err
 	}
 	if c.TotalTokens == "" {
-		return BigZero, nil
+		return new(big.Int), nil
 	}
 	return BigIntString(c.TotalTokens)
 }
@@ -214,10 +272,173 @@ func (c *DefaultContract) TokensOwnedBy(owner string) ([]string, error) {
 	return nil, ErrNoExist
 }
 
+// Approve allows the approved address to transfer tokenID on behalf of owner. A token
+// has at most one approved address at a time; approving "" clears the approval.
+// The approval is cleared automatically when the token is transferred or burned.
+// The caller must be the owner or one of the owner's approved operators. Soulbound
+// tokens cannot be approved.
+func (c *DefaultContract) Approve(owner, approved, tokenID string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	currentOwner, err := c.OwnerOf(tokenID)
+	if err != nil {
+		return err
+	}
+	if currentOwner != owner {
+		return ErrNotOwner
+	}
+	if err := c.loadSoulbound(); err != nil {
+		return err
+	}
+	if c.isSoulbound(tokenID) {
+		return ErrSoulbound
+	}
+	if c.caller == "" {
+		return ErrUnauthorized
+	}
+	if c.caller != owner {
+		ok, err := c.IsApprovedForAll(owner, c.caller)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrUnauthorized
+		}
+	}
+	if c.TokenApprovals == nil {
+		if err := c.fetchTokenApprovals(); err != nil {
+			return err
+		}
+	}
+	if approved == "" {
+		delete(c.TokenApprovals, tokenID)
+	} else {
+		c.TokenApprovals[tokenID] = approved
+	}
+	c.emit(Event{Type: EventApproval, From: owner, To: approved, TokenID: tokenID})
+	return nil
+}
+
+// GetApproved returns the address approved to transfer tokenID, or "" if there is none.
+func (c *DefaultContract) GetApproved(tokenID string) (string, error) {
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return "", err
+	}
+	if c.TokenApprovals == nil {
+		if err := c.fetchTokenApprovals(); err != nil {
+			return "", err
+		}
+	}
+	return c.TokenApprovals[tokenID], nil
+}
+
+// SetApprovalForAll allows or disallows operator to manage all of owner's tokens.
+// Only the owner may change its own operators. Operators cannot be approved in a soulbound
+// collection, and approved operators cannot move soulbound tokens.
+func (c *DefaultContract) SetApprovalForAll(owner, operator string, approved bool) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if c.Soulbound && approved {
+		return ErrSoulbound
+	}
+	if c.caller == "" || c.caller != owner {
+		return ErrUnauthorized
+	}
+	if c.OperatorApprovals == nil {
+		if err := c.fetchOperatorApprovals(); err != nil {
+			return err
+		}
+	}
+	if approved {
+		if c.OperatorApprovals[owner] == nil {
+			c.OperatorApprovals[owner] = make(map[string]bool)
+		}
+		c.OperatorApprovals[owner][operator] = true
+	} else {
+		delete(c.OperatorApprovals[owner], operator)
+		if len(c.OperatorApprovals[owner]) == 0 {
+			delete(c.OperatorApprovals, owner)
+		}
+	}
+	c.emit(Event{Type: EventApprovalForAll, From: owner, To: operator, Approved: approved})
+	return nil
+}
+
+// IsApprovedForAll reports whether operator is allowed to manage all of owner's tokens.
+func (c *DefaultContract) IsApprovedForAll(owner, operator string) (bool, error) {
+	if c.OperatorApprovals == nil {
+		if err := c.fetchOperatorApprovals(); err != nil {
+			return false, err
+		}
+	}
+	return c.OperatorApprovals[owner][operator], nil
+}
+
+// MarshalJSON encodes the heap output of the contract. Maps and lists that were never loaded
+// are nil and are left out, so that the heap keeps their current value. Loaded ones are always
+// written, even when they are empty, so that removing their last entry is persisted. The same
+// goes for the base URI and the allowlist root, which may be cleared by setting "".
+func (c *DefaultContract) MarshalJSON() ([]byte, error) {
+	// heapContract has the fields of DefaultContract but not its methods, so encoding it
+	// does not recurse into MarshalJSON.
+	type heapContract DefaultContract
+	loaded := make(map[string]interface{})
+	if c.baseURILoaded {
+		loaded["baseURI"] = c.BaseTokenURI
+	}
+	if c.allowlistRootLoaded {
+		loaded["allowlistRoot"] = c.AllowlistMerkleRoot
+	}
+	return marshalHeap((*heapContract)(c), loaded)
+}
+
+// marshalHeap JSON encodes heap, a pointer to a struct, keeping every non-nil map and list
+// field even when it is empty and would otherwise be left out by omitempty. Every value in
+// loaded is written under its key whether or not it is empty.
+func marshalHeap(heap interface{}, loaded map[string]interface{}) ([]byte, error) {
+	b, err := json.Marshal(heap)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(heap).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || key == "" || key == "-" {
+			continue
+		}
+		if kind := value.Kind(); kind != reflect.Map && kind != reflect.Slice {
+			continue
+		}
+		if value.IsNil() || value.Len() != 0 {
+			continue
+		}
+		if fields[key], err = json.Marshal(value.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range loaded {
+		if fields[key], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
 // GetDragonObject fetches an object with the provided key from the DragonChain smart
 // contract's heap. An error is returned if the object could not be fetched.
 func (c *DefaultContract) GetDragonObject(key string) ([]byte, error) {
-	resp, err := c.client.GetSmartContractObject(key, "")
+	return getDragonObject(c.client, key)
+}
+
+func getDragonObject(client Client, key string) ([]byte, error) {
+	resp, err := client.GetSmartContractObject(key, "")
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +452,233 @@ func (c *DefaultContract) GetDragonObject(key string) ([]byte, error) {
 	return resp.Response.([]byte), nil
 }
 
-func (c *DefaultContract) removeToken(from, tid string) error {
+// authorizeToken returns ErrUnauthorized unless the caller may act on tokenID, which is
+// owned by owner.
+func (c *DefaultContract) authorizeToken(owner, tokenID string) error {
+	ok, err := c.isApprovedOrOwner(c.caller, owner, tokenID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// authorizeMint returns ErrUnauthorized unless the caller holds MinterRole.
+func (c *DefaultContract) authorizeMint() error {
+	return c.requireRole(MinterRole)
+}
+
+// isApprovedOrOwner reports whether spender may act on tokenID, which is owned by owner.
+func (c *DefaultContract) isApprovedOrOwner(spender, owner, tokenID string) (bool, error) {
+	if spender == "" {
+		return false, nil
+	}
+	if spender == owner {
+		return true, nil
+	}
+	approved, err := c.GetApproved(tokenID)
+	if err != nil {
+		return false, err
+	}
+	if approved == spender {
+		return true, nil
+	}
+	return c.IsApprovedForAll(owner, spender)
+}
+
+// Every state change is split into three steps so that batches can be applied atomically:
+// a load step fetches everything the change touches from the heap, a check step validates
+// and authorizes a single token without changing anything, and an apply step performs the
+// change and cannot fail.
+
+// loadMint loads everything minting touches. It returns the current total supply. The
+// caller must be authorized separately.
+func (c *DefaultContract) loadMint() (*big.Int, error) {
+	if err := c.whenNotPaused(); err != nil {
+		return nil, err
+	}
+	if err := c.loadOwnership(); err != nil {
+		return nil, err
+	}
+	if err := c.loadTokenList(); err != nil {
+		return nil, err
+	}
+	if err := c.loadMintCaps(); err != nil {
+		return nil, err
+	}
+	if err := c.loadHistory(); err != nil {
+		return nil, err
+	}
+	return c.TotalSupply()
+}
+
+// checkMint checks that tokenID can be minted.
+func (c *DefaultContract) checkMint(tokenID string) error {
+	// If the token already exists, we don't want to remint it.
+	if _, ok := c.TokenOwners[tokenID]; ok {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// mintToken assigns a new token to the "to" address. It does not change the total supply.
+func (c *DefaultContract) mintToken(to, tokenID string) {
+	c.addToken(to, tokenID)
+	c.addToTokenList(tokenID)
+	c.MintedCounts[to]++
+	// loadMintCaps has already checked that MintedTokens is valid.
+	minted, _ := BigIntString(c.MintedTokens)
+	c.MintedTokens = minted.Add(minted, bigOne).String()
+	c.recordProvenance(tokenID, EventMint, "", to)
+	c.emit(Event{Type: EventMint, To: to, TokenID: tokenID})
+}
+
+// loadBurn loads everything burning touches. It returns the current total supply.
+func (c *DefaultContract) loadBurn() (*big.Int, error) {
+	if err := c.whenNotPaused(); err != nil {
+		return nil, err
+	}
+	if err := c.loadOwnership(); err != nil {
+		return nil, err
+	}
+	if c.TokenApprovals == nil {
+		if err := c.fetchTokenApprovals(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.loadTokenList(); err != nil {
+		return nil, err
+	}
+	if c.TokenURIs == nil {
+		if err := c.fetchTokenURIs(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.loadAttributes(); err != nil {
+		return nil, err
+	}
+	if c.TokenRoyalties == nil {
+		if err := c.fetchTokenRoyalties(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.loadHistory(); err != nil {
+		return nil, err
+	}
+	if err := c.loadSoulbound(); err != nil {
+		return nil, err
+	}
+	if err := c.loadRentals(); err != nil {
+		return nil, err
+	}
+	// Contracts that minted tokens before the minted count was kept start counting from their
+	// total supply, so the count has to be loaded before burning lowers the supply.
+	if _, err := c.TotalMinted(); err != nil {
+		return nil, err
+	}
+	return c.TotalSupply()
+}
+
+// checkBurn checks that the caller may burn tokenID and returns the token's owner.
+func (c *DefaultContract) checkBurn(tokenID string) (string, error) {
+	owner, ok := c.TokenOwners[tokenID]
+	if !ok || !c.ownsToken(owner, tokenID) {
+		return "", ErrNoExist
+	}
+	if c.isSoulbound(tokenID) {
+		return owner, c.authorizeSoulboundBurn(owner)
+	}
+	if err := c.authorizeToken(owner, tokenID); err != nil {
+		if err != ErrUnauthorized {
+			return "", err
+		}
+		// Burners may destroy any token.
+		if err := c.requireRole(BurnerRole); err != nil {
+			return "", err
+		}
+	}
+	return owner, nil
+}
+
+// burnToken destroys a token and everything attached to it. It does not change the total supply.
+func (c *DefaultContract) burnToken(owner, tokenID string) {
+	c.removeToken(owner, tokenID)
+	c.removeFromTokenList(tokenID)
+	delete(c.TokenApprovals, tokenID)
+	delete(c.TokenURIs, tokenID)
+	c.removeAttributes(tokenID)
+	delete(c.TokenRoyalties, tokenID)
+	delete(c.SoulboundTokens, tokenID)
+	delete(c.TokenUsers, tokenID)
+	c.recordProvenance(tokenID, EventBurn, owner, "")
+	c.emit(Event{Type: EventBurn, From: owner, TokenID: tokenID})
+}
+
+// loadTransfer loads everything a transfer touches.
+func (c *DefaultContract) loadTransfer() error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.loadOwnership(); err != nil {
+		return err
+	}
+	if c.TokenApprovals == nil {
+		if err := c.fetchTokenApprovals(); err != nil {
+			return err
+		}
+	}
+	if err := c.loadSoulbound(); err != nil {
+		return err
+	}
+	if err := c.loadRentals(); err != nil {
+		return err
+	}
+	return c.loadHistory()
+}
+
+// checkTransfer checks that the caller may move tokenID out of the "from" address.
+func (c *DefaultContract) checkTransfer(from, tokenID string) error {
+	if _, ok := c.OwnedTokenIndex[tokenID]; !ok {
+		return ErrNoExist
+	}
+	// Make sure the from address has tokens to begin with.
+	if _, ok := c.OwnedTokens[from]; !ok {
+		return ErrNoExist
+	}
+	// Make sure the token is actually owned by the from address.
+	if c.TokenOwners[tokenID] != from || !c.ownsToken(from, tokenID) {
+		return ErrNotOwner
+	}
+	if c.isSoulbound(tokenID) {
+		return ErrSoulbound
+	}
+	return c.authorizeToken(from, tokenID)
+}
+
+// prepareTransfer loads and checks a single transfer.
+func (c *DefaultContract) prepareTransfer(from, tokenID string) error {
+	if err := c.loadTransfer(); err != nil {
+		return err
+	}
+	return c.checkTransfer(from, tokenID)
+}
+
+// transferToken moves tokenID from the "from" address to the "to" address.
+func (c *DefaultContract) transferToken(from, to, tokenID string) {
+	c.removeToken(from, tokenID)
+	// approvals and rentals never survive a change of ownership
+	delete(c.TokenApprovals, tokenID)
+	delete(c.TokenUsers, tokenID)
+	c.addToken(to, tokenID)
+	c.recordProvenance(tokenID, EventTransfer, from, to)
+	c.emit(Event{Type: EventTransfer, From: from, To: to, TokenID: tokenID})
+}
+
+// loadOwnership fetches the ownership bookkeeping from the heap if it has not been
+// loaded yet.
+func (c *DefaultContract) loadOwnership() error {
 	if c.TokenOwners == nil {
 		if err := c.fetchTokenOwners(); err != nil {
 			return err
@@ -247,23 +694,46 @@ func (c *DefaultContract) removeToken(from, tid string) error {
 			return err
 		}
 	}
-	totalTokens, err := c.TotalSupply()
-	if err != nil {
-		return err
-	}
+	return nil
+}
+
+// ownsToken reports whether tid is recorded in the owned token list of owner at the
+// position given by OwnedTokenIndex.
+func (c *DefaultContract) ownsToken(owner, tid string) bool {
 	tokenIndex, ok := c.OwnedTokenIndex[tid]
 	if !ok {
-		return ErrNoExist
+		return false
 	}
-	// remove token from "from" address
-	delete(c.TokenOwners, tid)
-	c.OwnedTokens[from] = append(c.OwnedTokens[from][:tokenIndex], c.OwnedTokens[from][tokenIndex+1:]...)
-	if len(c.OwnedTokens[from]) == 0 {
+	tokens := c.OwnedTokens[owner]
+	return tokenIndex < uint64(len(tokens)) && tokens[tokenIndex] == tid
+}
+
+// addToken assigns tid to the "to" address. The ownership bookkeeping must be loaded.
+func (c *DefaultContract) addToken(to, tid string) {
+	c.TokenOwners[tid] = to
+	c.OwnedTokenIndex[tid] = uint64(len(c.OwnedTokens[to]))
+	c.OwnedTokens[to] = append(c.OwnedTokens[to], tid)
+}
+
+// removeToken removes tid from the "from" address. The last token owned by "from" is
+// moved into the freed slot so that every remaining index stays correct without
+// shifting the list. The ownership bookkeeping must be loaded and "from" must own tid.
+func (c *DefaultContract) removeToken(from, tid string) {
+	tokens := c.OwnedTokens[from]
+	tokenIndex := c.OwnedTokenIndex[tid]
+	lastIndex := uint64(len(tokens) - 1)
+	if tokenIndex != lastIndex {
+		lastToken := tokens[lastIndex]
+		tokens[tokenIndex] = lastToken
+		c.OwnedTokenIndex[lastToken] = tokenIndex
+	}
+	if lastIndex == 0 {
 		delete(c.OwnedTokens, from)
+	} else {
+		c.OwnedTokens[from] = tokens[:lastIndex]
 	}
 	delete(c.OwnedTokenIndex, tid)
-	c.TotalTokens = totalTokens.Sub(totalTokens, bigOne).String()
-	return nil
+	delete(c.TokenOwners, tid)
 }
 
 func (c *DefaultContract) fetchOwnedTokens() error {
@@ -317,8 +787,42 @@ func (c *DefaultContract) fetchOwnedTokenIndices() error {
 	return nil
 }
 
+func (c *DefaultContract) fetchTokenApprovals() error {
+	resp, err := c.GetDragonObject("tokenApprovals")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenApprovals = make(map[string]string)
+		return nil
+	}
+	var m map[string]string
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenApprovals = m
+	return nil
+}
+
+func (c *DefaultContract) fetchOperatorApprovals() error {
+	resp, err := c.GetDragonObject("operatorApprovals")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.OperatorApprovals = make(map[string]map[string]bool)
+		return nil
+	}
+	var m map[string]map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.OperatorApprovals = m
+	return nil
+}
+
 func (c *DefaultContract) fetchTotalSupply() error {
-	resp, err := c.GetDragonObject("totalSupply")
+	resp, err := c.GetDragonObject("totalTokens")
 	if err != nil {
 		return err
 	}
@@ -343,22 +847,52 @@ func BigIntString(s string) (*big.Int, error) {
 // DefaultContractFactory creates a new DefaultContract from the heap.
 type DefaultContractFactory struct{}
 
-// CreateContract returns a new DefaultContract.
+// CreateContract returns a new DefaultContract configured from the process environment, as
+// described for CreateContractFromEnv.
 func (f *DefaultContractFactory) CreateContract(name, symbol string) (Contract, error) {
-	dcClient, err := dragonClient()
+	return f.CreateContractFromEnv(name, symbol, environ())
+}
+
+// CreateContractFromEnv returns a new DefaultContract. The contract's minter and admin are read
+// from the CONTRACT_MINTER and CONTRACT_ADMIN variables of env. Setting CONTRACT_SOULBOUND to
+// true makes the whole collection soulbound.
+func (f *DefaultContractFactory) CreateContractFromEnv(name, symbol string, env map[string]string) (Contract, error) {
+	dcClient, err := dragonClient(env)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dragonchain client: %s", err)
 	}
-	return NewDefaultContract(name, symbol, dcClient), nil
+	contract := NewDefaultContract(name, symbol, dcClient)
+	contract.ContractMinter = env["CONTRACT_MINTER"]
+	contract.ContractAdmin = env["CONTRACT_ADMIN"]
+	if soulbound := env["CONTRACT_SOULBOUND"]; soulbound != "" {
+		if contract.Soulbound, err = strconv.ParseBool(soulbound); err != nil {
+			return nil, fmt.Errorf("invalid CONTRACT_SOULBOUND: %s", err)
+		}
+	}
+	return contract, nil
 }
 
-func dragonClient() (*dragonchain.Client, error) {
+// dragonClient returns a DragonChain client for the DRAGONCHAIN_ENDPOINT variable of env. The
+// credentials are read from the DRAGONCHAIN_ID, AUTH_KEY and AUTH_KEY_ID variables of env; the
+// SDK looks up any that are missing in the process environment and its credentials file.
+func dragonClient(env map[string]string) (*dragonchain.Client, error) {
 	httpClient := &http.Client{}
-	creds, err := dragonchain.NewCredentials("", "", "", dragonchain.HashSHA256)
+	creds, err := dragonchain.NewCredentials(env[dragonchain.EnvDcIDName], env[dragonchain.EnvKeyName], env[dragonchain.EnvKeyIDName], dragonchain.HashSHA256)
 	if err != nil {
 		return nil, err
 	}
-	baseAPIURL := os.Getenv("DRAGONCHAIN_ENDPOINT")
+	baseAPIURL := env["DRAGONCHAIN_ENDPOINT"]
 	client := dragonchain.NewClient(creds, baseAPIURL, httpClient)
 	return client, nil
 }
+
+// environ returns the process environment as a map.
+func environ() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+	return env
+}
@@ -1,13 +1,17 @@
 package nft
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"math/rand"
 	"net/http"
 	"testing"
 
 	"github.com/dragonchain/dragonchain-sdk-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type dcResp struct {
@@ -124,6 +128,7 @@ var (
 		OwnedTokens   map[string][]string
 		TokenIndicies map[string]uint64
 		TotalSupply   *big.Int
+		Caller        string
 		From          string
 		TokenID       string
 		ExpectedError error
@@ -133,6 +138,7 @@ var (
 			OwnedTokens:   map[string][]string{"owner": {"tokenID"}},
 			TokenIndicies: map[string]uint64{"tokenID": 0},
 			TotalSupply:   bigOne,
+			Caller:        "owner",
 			From:          "owner",
 			TokenID:       "tokenID",
 		},
@@ -141,6 +147,7 @@ var (
 			OwnedTokens:   map[string][]string{},
 			TokenIndicies: map[string]uint64{},
 			TotalSupply:   BigZero,
+			Caller:        "owner",
 			From:          "owner",
 			TokenID:       "tokenID",
 			ExpectedError: ErrNoExist,
@@ -148,14 +155,16 @@ var (
 	}
 
 	transferTests = map[string]struct {
-		TokenOwners   map[string]string
-		OwnedTokens   map[string][]string
-		TokenIndicies map[string]uint64
-		TotalSupply   *big.Int
-		To            string
-		From          string
-		TokenID       string
-		ExpectedError error
+		TokenOwners       map[string]string
+		OwnedTokens       map[string][]string
+		TokenIndicies     map[string]uint64
+		OperatorApprovals map[string]map[string]bool
+		TotalSupply       *big.Int
+		Caller            string
+		To                string
+		From              string
+		TokenID           string
+		ExpectedError     error
 	}{
 		"token transfered": {
 			TokenOwners:   map[string]string{"tokenID": "owner"},
@@ -163,14 +172,60 @@ var (
 			TokenIndicies: map[string]uint64{"tokenID": 0},
 			TotalSupply:   bigOne,
 			To:            "owner2",
+			Caller:        "owner",
 			From:          "owner",
 			TokenID:       "tokenID",
 		},
+		"approved caller": {
+			TokenOwners:   map[string]string{"tokenID": "owner"},
+			OwnedTokens:   map[string][]string{"owner": {"tokenID"}},
+			TokenIndicies: map[string]uint64{"tokenID": 0},
+			TotalSupply:   bigOne,
+			Caller:        "approved",
+			To:            "owner2",
+			From:          "owner",
+			TokenID:       "tokenID",
+		},
+		"operator caller": {
+			TokenOwners:       map[string]string{"tokenID": "owner"},
+			OwnedTokens:       map[string][]string{"owner": {"tokenID"}},
+			TokenIndicies:     map[string]uint64{"tokenID": 0},
+			OperatorApprovals: map[string]map[string]bool{"owner": {"operator": true}},
+			TotalSupply:       bigOne,
+			Caller:            "operator",
+			To:                "owner2",
+			From:              "owner",
+			TokenID:           "tokenID",
+		},
+		"unauthorized caller": {
+			TokenOwners:       map[string]string{"tokenID": "owner"},
+			OwnedTokens:       map[string][]string{"owner": {"tokenID"}},
+			TokenIndicies:     map[string]uint64{"tokenID": 0},
+			OperatorApprovals: map[string]map[string]bool{"owner2": {"operator": true}},
+			TotalSupply:       bigOne,
+			Caller:            "operator",
+			To:                "owner2",
+			From:              "owner",
+			TokenID:           "tokenID",
+			ExpectedError:     ErrUnauthorized,
+		},
+		"from not owner": {
+			TokenOwners:   map[string]string{"tokenID": "owner"},
+			OwnedTokens:   map[string][]string{"owner": {"tokenID"}, "owner2": {"tokenID2"}},
+			TokenIndicies: map[string]uint64{"tokenID": 0, "tokenID2": 0},
+			TotalSupply:   big.NewInt(2),
+			Caller:        "owner2",
+			To:            "owner3",
+			From:          "owner2",
+			TokenID:       "tokenID",
+			ExpectedError: ErrNotOwner,
+		},
 		"token index no exist": {
 			TokenOwners:   map[string]string{},
 			OwnedTokens:   map[string][]string{},
 			TokenIndicies: map[string]uint64{},
 			TotalSupply:   BigZero,
+			Caller:        "owner",
 			From:          "owner",
 			TokenID:       "tokenID",
 			ExpectedError: ErrNoExist,
@@ -180,12 +235,275 @@ var (
 			OwnedTokens:   map[string][]string{},
 			TokenIndicies: map[string]uint64{"tokenID": 0},
 			TotalSupply:   BigZero,
+			Caller:        "owner",
 			From:          "owner",
 			TokenID:       "tokenID",
 			ExpectedError: ErrNoExist,
 		},
 	}
 
+	approveTests = map[string]struct {
+		TokenOwners       map[string]string
+		TokenApprovals    map[string]string
+		Owner             string
+		Approved          string
+		TokenID           string
+		ExpectedApprovals map[string]string
+		ExpectedError     error
+	}{
+		"token approved": {
+			TokenOwners:       map[string]string{"tokenID": "owner"},
+			TokenApprovals:    map[string]string{},
+			Owner:             "owner",
+			Approved:          "approved",
+			TokenID:           "tokenID",
+			ExpectedApprovals: map[string]string{"tokenID": "approved"},
+		},
+		"approval replaced": {
+			TokenOwners:       map[string]string{"tokenID": "owner"},
+			TokenApprovals:    map[string]string{"tokenID": "approved"},
+			Owner:             "owner",
+			Approved:          "approved2",
+			TokenID:           "tokenID",
+			ExpectedApprovals: map[string]string{"tokenID": "approved2"},
+		},
+		"approval cleared": {
+			TokenOwners:       map[string]string{"tokenID": "owner"},
+			TokenApprovals:    map[string]string{"tokenID": "approved"},
+			Owner:             "owner",
+			TokenID:           "tokenID",
+			ExpectedApprovals: map[string]string{},
+		},
+		"not owner": {
+			TokenOwners:       map[string]string{"tokenID": "owner"},
+			TokenApprovals:    map[string]string{},
+			Owner:             "owner2",
+			Approved:          "approved",
+			TokenID:           "tokenID",
+			ExpectedApprovals: map[string]string{},
+			ExpectedError:     ErrNotOwner,
+		},
+		"token no exist": {
+			TokenOwners:       map[string]string{},
+			TokenApprovals:    map[string]string{},
+			Owner:             "owner",
+			Approved:          "approved",
+			TokenID:           "tokenID",
+			ExpectedApprovals: map[string]string{},
+			ExpectedError:     ErrNoExist,
+		},
+	}
+
+	getApprovedTests = map[string]struct {
+		DCResponse       *dcResp
+		TokenOwners      map[string]string
+		DefaultState     map[string]string
+		TokenID          string
+		ExpectedApproved string
+		ExpectedError    error
+	}{
+		"fetch": {
+			DCResponse: &dcResp{
+				Response: `{"tokenID": "approved"}`,
+			},
+			TokenOwners:      map[string]string{"tokenID": "owner"},
+			TokenID:          "tokenID",
+			ExpectedApproved: "approved",
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			TokenOwners:   map[string]string{"tokenID": "owner"},
+			TokenID:       "tokenID",
+			ExpectedError: errFailed,
+		},
+		"approval exists": {
+			TokenOwners:      map[string]string{"tokenID": "owner"},
+			DefaultState:     map[string]string{"tokenID": "approved"},
+			TokenID:          "tokenID",
+			ExpectedApproved: "approved",
+		},
+		"no approval": {
+			TokenOwners:  map[string]string{"tokenID": "owner"},
+			DefaultState: map[string]string{},
+			TokenID:      "tokenID",
+		},
+		"token no exist": {
+			TokenOwners:   map[string]string{},
+			TokenID:       "tokenID",
+			ExpectedError: ErrNoExist,
+		},
+	}
+
+	setApprovalForAllTests = map[string]struct {
+		DefaultState      map[string]map[string]bool
+		Owner             string
+		Operator          string
+		Approved          bool
+		ExpectedApprovals map[string]map[string]bool
+	}{
+		"operator approved": {
+			DefaultState:      map[string]map[string]bool{},
+			Owner:             "owner",
+			Operator:          "operator",
+			Approved:          true,
+			ExpectedApprovals: map[string]map[string]bool{"owner": {"operator": true}},
+		},
+		"second operator approved": {
+			DefaultState:      map[string]map[string]bool{"owner": {"operator": true}},
+			Owner:             "owner",
+			Operator:          "operator2",
+			Approved:          true,
+			ExpectedApprovals: map[string]map[string]bool{"owner": {"operator": true, "operator2": true}},
+		},
+		"operator revoked": {
+			DefaultState:      map[string]map[string]bool{"owner": {"operator": true, "operator2": true}},
+			Owner:             "owner",
+			Operator:          "operator",
+			ExpectedApprovals: map[string]map[string]bool{"owner": {"operator2": true}},
+		},
+		"last operator revoked": {
+			DefaultState:      map[string]map[string]bool{"owner": {"operator": true}},
+			Owner:             "owner",
+			Operator:          "operator",
+			ExpectedApprovals: map[string]map[string]bool{},
+		},
+		"revoke unknown operator": {
+			DefaultState:      map[string]map[string]bool{},
+			Owner:             "owner",
+			Operator:          "operator",
+			ExpectedApprovals: map[string]map[string]bool{},
+		},
+	}
+
+	isApprovedForAllTests = map[string]struct {
+		DCResponse       *dcResp
+		DefaultState     map[string]map[string]bool
+		Owner            string
+		Operator         string
+		ExpectedApproved bool
+		ExpectedError    error
+	}{
+		"fetch": {
+			DCResponse: &dcResp{
+				Response: `{"owner": {"operator": true}}`,
+			},
+			Owner:            "owner",
+			Operator:         "operator",
+			ExpectedApproved: true,
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			Owner:         "owner",
+			Operator:      "operator",
+			ExpectedError: errFailed,
+		},
+		"approved in memory": {
+			DefaultState:     map[string]map[string]bool{"owner": {"operator": true}},
+			Owner:            "owner",
+			Operator:         "operator",
+			ExpectedApproved: true,
+		},
+		"not approved": {
+			DefaultState: map[string]map[string]bool{"owner": {"operator": true}},
+			Owner:        "owner2",
+			Operator:     "operator",
+		},
+	}
+
+	authorizationTests = map[string]struct {
+		Caller        string
+		Operation     func(c *DefaultContract) error
+		ExpectedError error
+	}{
+		"mint by minter": {
+			Caller:    "minter",
+			Operation: func(c *DefaultContract) error { return c.Mint("owner2", "tokenID3") },
+		},
+		"mint by non-minter": {
+			Caller:        "owner",
+			Operation:     func(c *DefaultContract) error { return c.Mint("owner", "tokenID3") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"mint without caller": {
+			Operation:     func(c *DefaultContract) error { return c.Mint("owner", "tokenID3") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"transfer by owner": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+		},
+		"transfer by approved": {
+			Caller:    "approved",
+			Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+		},
+		"transfer by operator": {
+			Caller:    "operator",
+			Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+		},
+		"transfer by stranger": {
+			Caller:        "stranger",
+			Operation:     func(c *DefaultContract) error { return c.Transfer("owner", "stranger", "tokenID") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"transfer by approved of other token": {
+			Caller:        "approved",
+			Operation:     func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID2") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"transfer without caller": {
+			Operation:     func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID2") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"burn by owner": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Burn("tokenID") },
+		},
+		"burn by approved": {
+			Caller:    "approved",
+			Operation: func(c *DefaultContract) error { return c.Burn("tokenID") },
+		},
+		"burn by operator": {
+			Caller:    "operator",
+			Operation: func(c *DefaultContract) error { return c.Burn("tokenID2") },
+		},
+		"burn by stranger": {
+			Caller:        "stranger",
+			Operation:     func(c *DefaultContract) error { return c.Burn("tokenID") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"burn by minter": {
+			Caller:        "minter",
+			Operation:     func(c *DefaultContract) error { return c.Burn("tokenID") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"approve by owner": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Approve("owner", "approved2", "tokenID2") },
+		},
+		"approve by operator": {
+			Caller:    "operator",
+			Operation: func(c *DefaultContract) error { return c.Approve("owner", "approved2", "tokenID2") },
+		},
+		"approve by approved": {
+			Caller:        "approved",
+			Operation:     func(c *DefaultContract) error { return c.Approve("owner", "approved2", "tokenID") },
+			ExpectedError: ErrUnauthorized,
+		},
+		"set operator by owner": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.SetApprovalForAll("owner", "operator2", true) },
+		},
+		"set operator by operator": {
+			Caller:        "operator",
+			Operation:     func(c *DefaultContract) error { return c.SetApprovalForAll("owner", "operator2", true) },
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
 	totalSupplyTests = map[string]struct {
 		DCResponse     *dcResp
 		DefaultState   string
@@ -303,11 +621,14 @@ func TestDefaultContract_Mint(t *testing.T) {
 	for name, test := range mintTests {
 		t.Run(name, func(t *testing.T) {
 			mockClient := &MockClient{}
+			emptyHeap(mockClient)
 			contract := NewDefaultContract("test", "TEST", mockClient)
 			contract.TokenOwners = test.TokenOwners
 			contract.OwnedTokens = test.OwnedTokens
 			contract.OwnedTokenIndex = test.TokenIndicies
 			contract.TotalTokens = test.TotalSupply.String()
+			contract.ContractMinter = "minter"
+			contract.SetCaller("minter")
 			err := contract.Mint(test.To, test.TokenID)
 			assert.Equal(t, test.ExpectedError, err)
 			assert.Len(t, contract.TokenOwners, 1)
@@ -325,11 +646,15 @@ func TestDefaultContract_Burn(t *testing.T) {
 	for name, test := range burnTests {
 		t.Run(name, func(t *testing.T) {
 			mockClient := &MockClient{}
+			emptyHeap(mockClient)
 			contract := NewDefaultContract("test", "TEST", mockClient)
 			contract.TokenOwners = test.TokenOwners
 			contract.OwnedTokens = test.OwnedTokens
 			contract.OwnedTokenIndex = test.TokenIndicies
 			contract.TotalTokens = test.TotalSupply.String()
+			contract.TokenApprovals = map[string]string{test.TokenID: "approved"}
+			contract.TokenURIs = map[string]string{test.TokenID: "uri"}
+			contract.SetCaller(test.Caller)
 			expectedOwners := len(test.TokenOwners) - 1
 			expectedTokens := len(test.OwnedTokens) - 1
 			expectedIndeices := len(test.TokenIndicies) - 1
@@ -342,8 +667,10 @@ func TestDefaultContract_Burn(t *testing.T) {
 			assert.Len(t, contract.TokenOwners, expectedOwners)
 			assert.Len(t, contract.OwnedTokens, expectedTokens)
 			assert.Len(t, contract.OwnedTokenIndex, expectedIndeices)
-			n := test.TotalSupply.Sub(test.TotalSupply, bigOne)
+			n := new(big.Int).Sub(test.TotalSupply, bigOne)
 			assert.Equal(t, n.String(), contract.TotalTokens)
+			assert.NotContains(t, contract.TokenApprovals, test.TokenID)
+			assert.NotContains(t, contract.TokenURIs, test.TokenID)
 		})
 	}
 }
@@ -352,11 +679,15 @@ func TestDefaultContract_Transfer(t *testing.T) {
 	for name, test := range transferTests {
 		t.Run(name, func(t *testing.T) {
 			mockClient := &MockClient{}
+			emptyHeap(mockClient)
 			contract := NewDefaultContract("test", "TEST", mockClient)
 			contract.TokenOwners = test.TokenOwners
 			contract.OwnedTokens = test.OwnedTokens
 			contract.OwnedTokenIndex = test.TokenIndicies
 			contract.TotalTokens = test.TotalSupply.String()
+			contract.TokenApprovals = map[string]string{test.TokenID: "approved"}
+			contract.OperatorApprovals = test.OperatorApprovals
+			contract.SetCaller(test.Caller)
 			expectedOwners := len(test.TokenOwners)
 			expectedTokens := len(test.OwnedTokens)
 			expectedIndeices := len(test.TokenIndicies)
@@ -373,6 +704,7 @@ func TestDefaultContract_Transfer(t *testing.T) {
 			assert.Equal(t, test.To, contract.TokenOwners[test.TokenID])
 			assert.Contains(t, contract.OwnedTokens[test.To], test.TokenID)
 			assert.Equal(t, uint64(0), contract.OwnedTokenIndex[test.TokenID])
+			assert.NotContains(t, contract.TokenApprovals, test.TokenID)
 		})
 	}
 }
@@ -393,13 +725,13 @@ func TestDefaultContract_TotalSupply(t *testing.T) {
 						Response: []byte(test.DCResponse.Response),
 					}
 				}
-				mockClient.On("GetSmartContractObject", "totalSupply", "").Once().Return(ret, test.DCResponse.Error)
+				mockClient.On("GetSmartContractObject", "totalTokens", "").Once().Return(ret, test.DCResponse.Error)
 			}
 			supply, err := contract.TotalSupply()
 			assert.Equal(t, test.ExpectedError, err)
 			assert.Equal(t, test.ExpectedSupply, supply.String())
 			if !on {
-				mockClient.AssertNotCalled(t, "GetSmartContractObject", "totalSupply", "")
+				mockClient.AssertNotCalled(t, "GetSmartContractObject", "totalTokens", "")
 			}
 		})
 	}
@@ -432,3 +764,271 @@ func TestDefaultContract_TokensOwnedBy(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultContract_Approve(t *testing.T) {
+	for name, test := range approveTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = test.TokenOwners
+			contract.TokenApprovals = test.TokenApprovals
+			contract.SetCaller(test.Owner)
+			err := contract.Approve(test.Owner, test.Approved, test.TokenID)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedApprovals, contract.TokenApprovals)
+		})
+	}
+}
+
+func TestDefaultContract_GetApproved(t *testing.T) {
+	for name, test := range getApprovedTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = test.TokenOwners
+			contract.TokenApprovals = test.DefaultState
+			on := test.DCResponse != nil
+			var ret *dragonchain.Response
+			if on {
+				if test.DCResponse.Error == nil {
+					ret = &dragonchain.Response{
+						OK:       true,
+						Status:   http.StatusOK,
+						Response: []byte(test.DCResponse.Response),
+					}
+				}
+				mockClient.On("GetSmartContractObject", "tokenApprovals", "").Once().Return(ret, test.DCResponse.Error)
+			}
+			approved, err := contract.GetApproved(test.TokenID)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedApproved, approved)
+			if !on {
+				mockClient.AssertNotCalled(t, "GetSmartContractObject", "tokenApprovals", "")
+			}
+		})
+	}
+}
+
+func TestDefaultContract_SetApprovalForAll(t *testing.T) {
+	for name, test := range setApprovalForAllTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.OperatorApprovals = test.DefaultState
+			contract.SetCaller(test.Owner)
+			err := contract.SetApprovalForAll(test.Owner, test.Operator, test.Approved)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedApprovals, contract.OperatorApprovals)
+		})
+	}
+}
+
+func TestDefaultContract_IsApprovedForAll(t *testing.T) {
+	for name, test := range isApprovedForAllTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.OperatorApprovals = test.DefaultState
+			on := test.DCResponse != nil
+			var ret *dragonchain.Response
+			if on {
+				if test.DCResponse.Error == nil {
+					ret = &dragonchain.Response{
+						OK:       true,
+						Status:   http.StatusOK,
+						Response: []byte(test.DCResponse.Response),
+					}
+				}
+				mockClient.On("GetSmartContractObject", "operatorApprovals", "").Once().Return(ret, test.DCResponse.Error)
+			}
+			approved, err := contract.IsApprovedForAll(test.Owner, test.Operator)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedApproved, approved)
+			if !on {
+				mockClient.AssertNotCalled(t, "GetSmartContractObject", "operatorApprovals", "")
+			}
+		})
+	}
+}
+
+func TestDefaultContract_Authorization(t *testing.T) {
+	for name, test := range authorizationTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner", "tokenID2": "owner"}
+			contract.OwnedTokens = map[string][]string{"owner": {"tokenID", "tokenID2"}}
+			contract.OwnedTokenIndex = map[string]uint64{"tokenID": 0, "tokenID2": 1}
+			contract.TokenApprovals = map[string]string{"tokenID": "approved"}
+			contract.OperatorApprovals = map[string]map[string]bool{"owner": {"operator": true}}
+			contract.TotalTokens = "2"
+			contract.ContractMinter = "minter"
+			contract.SetCaller(test.Caller)
+			err := test.Operation(contract)
+			assert.Equal(t, test.ExpectedError, err)
+		})
+	}
+}
+
+func TestDefaultContract_OwnershipInvariants(t *testing.T) {
+	owners := []string{"owner", "owner2", "owner3", "owner4", "owner5"}
+	for seed := int64(1); seed <= 5; seed++ {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(seed))
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{}
+			contract.OwnedTokens = map[string][]string{}
+			contract.OwnedTokenIndex = map[string]uint64{}
+			contract.TokenApprovals = map[string]string{}
+			contract.OperatorApprovals = map[string]map[string]bool{}
+			contract.TokenList = []string{}
+			contract.TokenListIndex = map[string]uint64{}
+			contract.TotalTokens = "0"
+			contract.ContractMinter = "minter"
+			// expected holds the owner of every live token, independently of the contract.
+			expected := map[string]string{}
+			var live []string
+			nextID := 0
+			for step := 0; step < 2000; step++ {
+				switch op := rng.Intn(3); {
+				case op == 0 || len(live) == 0:
+					tokenID := fmt.Sprintf("token%d", nextID)
+					nextID++
+					to := owners[rng.Intn(len(owners))]
+					contract.SetCaller("minter")
+					if !assert.NoError(t, contract.Mint(to, tokenID)) {
+						return
+					}
+					expected[tokenID] = to
+					live = append(live, tokenID)
+				case op == 1:
+					tokenID := live[rng.Intn(len(live))]
+					from, to := expected[tokenID], owners[rng.Intn(len(owners))]
+					contract.SetCaller(from)
+					if !assert.NoError(t, contract.Transfer(from, to, tokenID)) {
+						return
+					}
+					expected[tokenID] = to
+				default:
+					i := rng.Intn(len(live))
+					tokenID := live[i]
+					contract.SetCaller(expected[tokenID])
+					if !assert.NoError(t, contract.Burn(tokenID)) {
+						return
+					}
+					delete(expected, tokenID)
+					live[i] = live[len(live)-1]
+					live = live[:len(live)-1]
+				}
+				if !assertOwnershipInvariants(t, contract, expected) {
+					t.Fatalf("invariants broken after step %d", step)
+				}
+			}
+		})
+	}
+}
+
+func assertOwnershipInvariants(t *testing.T, contract *DefaultContract, expected map[string]string) bool {
+	ok := assert.Equal(t, expected, contract.TokenOwners)
+	ok = assert.Len(t, contract.OwnedTokenIndex, len(expected)) && ok
+	ok = assert.Equal(t, fmt.Sprint(len(expected)), contract.TotalTokens) && ok
+	indexed := 0
+	for owner, tokens := range contract.OwnedTokens {
+		ok = assert.NotEmpty(t, tokens, "owner %s has an empty token list", owner) && ok
+		for i, tokenID := range tokens {
+			ok = assert.Equal(t, owner, expected[tokenID], "token %s listed under wrong owner", tokenID) && ok
+			ok = assert.Equal(t, uint64(i), contract.OwnedTokenIndex[tokenID], "token %s has a stale index", tokenID) && ok
+			indexed++
+		}
+	}
+	ok = assert.Equal(t, len(expected), indexed) && ok
+	ok = assert.Len(t, contract.TokenList, len(expected)) && ok
+	ok = assert.Len(t, contract.TokenListIndex, len(expected)) && ok
+	for i, tokenID := range contract.TokenList {
+		ok = assert.Contains(t, expected, tokenID) && ok
+		ok = assert.Equal(t, uint64(i), contract.TokenListIndex[tokenID], "token %s has a stale list index", tokenID) && ok
+	}
+	return ok
+}
+
+// emptyHeap makes every heap object that a test has not loaded into the contract
+// fetch as if it did not exist yet.
+func emptyHeap(client *MockClient) {
+	client.On("GetSmartContractObject", mock.Anything, "").Return(&dragonchain.Response{Status: http.StatusNotFound}, nil)
+}
+
+func TestDefaultContract_MarshalJSON(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	// Objects that were never loaded must be left alone.
+	assert.Equal(t, map[string]json.RawMessage{
+		"name":   json.RawMessage(`"test"`),
+		"symbol": json.RawMessage(`"TEST"`),
+	}, heapOutput(t, contract))
+
+	contract.TokenOwners = map[string]string{"tokenID": "alice"}
+	contract.OwnedTokens = map[string][]string{"alice": {"tokenID"}}
+	contract.OwnedTokenIndex = map[string]uint64{"tokenID": 0}
+	contract.TokenApprovals = map[string]string{}
+	contract.SetCaller("alice")
+	assert.NoError(t, contract.Approve("alice", "bob", "tokenID"))
+	assert.NoError(t, contract.Transfer("alice", "carol", "tokenID"))
+	heap := heapOutput(t, contract)
+	// The cleared approval must overwrite the one on the heap.
+	assert.JSONEq(t, `{}`, string(heap["tokenApprovals"]))
+	assert.JSONEq(t, `{"tokenID": "carol"}`, string(heap["tokenOwners"]))
+}
+
+func TestDefaultContract_MarshalJSONRevokedOperator(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetSmartContractObject", "operatorApprovals", "").Return(&dragonchain.Response{
+		OK:       true,
+		Status:   http.StatusOK,
+		Response: []byte(`{"owner": {"operator": true}}`),
+	}, nil)
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.SetApprovalForAll("owner", "operator", false))
+	// The revocation must overwrite the approval on the heap.
+	assert.JSONEq(t, `{}`, string(heapOutput(t, contract)["operatorApprovals"]))
+}
+
+// heapOutput returns the heap output of contract by key.
+func heapOutput(t *testing.T, contract Contract) map[string]json.RawMessage {
+	b, err := json.Marshal(contract)
+	assert.NoError(t, err)
+	var heap map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(b, &heap))
+	return heap
+}
+
+// memoryHeap is a Client backed by an in-memory heap.
+type memoryHeap map[string][]byte
+
+func (h memoryHeap) GetSmartContractObject(key, smartContractID string) (*dragonchain.Response, error) {
+	value, ok := h[key]
+	if !ok {
+		return &dragonchain.Response{Status: http.StatusNotFound}, nil
+	}
+	return &dragonchain.Response{OK: true, Status: http.StatusOK, Response: value}, nil
+}
+
+// persist stores the heap output of contract in the heap the way Dragonchain does at the end
+// of an invocation: strings are stored as they are and any other value as JSON.
+func (h memoryHeap) persist(t *testing.T, contract Contract) {
+	for key, value := range heapOutput(t, contract) {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			h[key] = []byte(s)
+		} else {
+			h[key] = value
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Method handles a single RPC method. params holds the raw "params" of the request, which is
+// null if the request had none. The returned object is handled like that of an RPCHandler.
+type Method func(params json.RawMessage, contract Contract) (interface{}, error)
+
+// Dispatcher is an RPCHandler that routes JSON-RPC style requests of the form
+//   {"method": "transfer", "params": {"from": "...", "to": "...", "tokenId": "..."}}
+// to a registered Method. NewDispatcher registers a method for every Contract method.
+// Methods that change the contract return the contract, so that its state is written to
+// the heap. Methods that only read the contract return the value that was read.
+type Dispatcher struct {
+	methods map[string]Method
+}
+
+type rpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// NewDispatcher returns a Dispatcher that handles the following methods, with their params:
+//   name, symbol, totalSupply
+//   mint              {"to", "tokenId"}
+//   transfer          {"from", "to", "tokenId"}
+//   burn              {"tokenId"}
+//   balanceOf         {"owner"}
+//   ownerOf           {"tokenId"}
+//   tokensOwnedBy     {"owner"}
+//   approve           {"owner", "approved", "tokenId"}
+//   getApproved       {"tokenId"}
+//   setApprovalForAll {"owner", "operator", "approved"}
+//   isApprovedForAll  {"owner", "operator"}
+func NewDispatcher() *Dispatcher {
+	d := &Dispatcher{methods: make(map[string]Method)}
+	d.Register("name", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		return contract.Name(), nil
+	})
+	d.Register("symbol", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		return contract.Symbol(), nil
+	})
+	d.Register("totalSupply", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		return contract.TotalSupply()
+	})
+	d.Register("mint", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			To      string `json:"to"`
+			TokenID string `json:"tokenId"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract, contract.Mint(p.To, p.TokenID)
+	})
+	d.Register("transfer", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			From    string `json:"from"`
+			To      string `json:"to"`
+			TokenID string `json:"tokenId"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract, contract.Transfer(p.From, p.To, p.TokenID)
+	})
+	d.Register("burn", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			TokenID string `json:"tokenId"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract, contract.Burn(p.TokenID)
+	})
+	d.Register("balanceOf", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			Owner string `json:"owner"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract.BalanceOf(p.Owner)
+	})
+	d.Register("ownerOf", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			TokenID string `json:"tokenId"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract.OwnerOf(p.TokenID)
+	})
+	d.Register("tokensOwnedBy", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			Owner string `json:"owner"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract.TokensOwnedBy(p.Owner)
+	})
+	d.Register("approve", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			Owner    string `json:"owner"`
+			Approved string `json:"approved"`
+			TokenID  string `json:"tokenId"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract, contract.Approve(p.Owner, p.Approved, p.TokenID)
+	})
+	d.Register("getApproved", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			TokenID string `json:"tokenId"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract.GetApproved(p.TokenID)
+	})
+	d.Register("setApprovalForAll", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			Owner    string `json:"owner"`
+			Operator string `json:"operator"`
+			Approved bool   `json:"approved"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract, contract.SetApprovalForAll(p.Owner, p.Operator, p.Approved)
+	})
+	d.Register("isApprovedForAll", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		var p struct {
+			Owner    string `json:"owner"`
+			Operator string `json:"operator"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return contract.IsApprovedForAll(p.Owner, p.Operator)
+	})
+	return d
+}
+
+// Register registers a Method under name, replacing any method already registered under it.
+func (d *Dispatcher) Register(name string, method Method) {
+	d.methods[name] = method
+}
+
+// HandleRPC parses input with ParseInvocation and dispatches the invocation's payload like
+// HandleInvocation.
+func (d *Dispatcher) HandleRPC(input []byte, contract Contract) (interface{}, error) {
+	inv, err := ParseInvocation(input)
+	if err != nil {
+		return nil, err
+	}
+	return d.HandleInvocation(context.Background(), inv, contract)
+}
+
+// HandleInvocation parses the payload of inv as a JSON-RPC style request and calls the Method
+// registered under its method name.
+func (d *Dispatcher) HandleInvocation(ctx context.Context, inv Invocation, contract Contract) (interface{}, error) {
+	var req rpcRequest
+	if err := json.Unmarshal(inv.Payload, &req); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+	method, ok := d.methods[req.Method]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, req.Method)
+	}
+	return method(req.Params, contract)
+}
+
+// DecodeParams decodes the params of a request into v, like the methods registered by
+// NewDispatcher do. Missing params decode as an empty object, and unknown params are rejected
+// so that misspelled params are not silently ignored. Errors wrap ErrInvalidRequest.
+func DecodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		params = json.RawMessage("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+	return nil
+}
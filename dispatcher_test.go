@@ -0,0 +1,154 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var dispatcherTests = map[string]struct {
+	Caller         string
+	Input          string
+	ExpectedResult interface{}
+	ExpectedError  error
+	Check          func(t *testing.T, c *DefaultContract)
+}{
+	"name": {
+		Input:          `{"method": "name"}`,
+		ExpectedResult: "test",
+	},
+	"symbol": {
+		Input:          `{"method": "symbol", "params": {}}`,
+		ExpectedResult: "TEST",
+	},
+	"total supply": {
+		Input:          `{"method": "totalSupply"}`,
+		ExpectedResult: big.NewInt(3),
+	},
+	"balance of": {
+		Input:          `{"method": "balanceOf", "params": {"owner": "owner"}}`,
+		ExpectedResult: uint64(2),
+	},
+	"owner of": {
+		Input:          `{"method": "ownerOf", "params": {"tokenId": "tokenID3"}}`,
+		ExpectedResult: "owner2",
+	},
+	"owner of missing token": {
+		Input:         `{"method": "ownerOf", "params": {"tokenId": "tokenID4"}}`,
+		ExpectedError: ErrNoExist,
+	},
+	"tokens owned by": {
+		Input:          `{"method": "tokensOwnedBy", "params": {"owner": "owner"}}`,
+		ExpectedResult: []string{"tokenID", "tokenID2"},
+	},
+	"get approved": {
+		Input:          `{"method": "getApproved", "params": {"tokenId": "tokenID2"}}`,
+		ExpectedResult: "approved",
+	},
+	"is approved for all": {
+		Input:          `{"method": "isApprovedForAll", "params": {"owner": "owner", "operator": "operator"}}`,
+		ExpectedResult: false,
+	},
+	"mint": {
+		Caller: "minter",
+		Input:  `{"method": "mint", "params": {"to": "owner2", "tokenId": "tokenID4"}}`,
+		Check: func(t *testing.T, c *DefaultContract) {
+			owner, err := c.OwnerOf("tokenID4")
+			assert.NoError(t, err)
+			assert.Equal(t, "owner2", owner)
+		},
+	},
+	"transfer": {
+		Caller: "owner",
+		Input:  `{"method": "transfer", "params": {"from": "owner", "to": "owner2", "tokenId": "tokenID"}}`,
+		Check: func(t *testing.T, c *DefaultContract) {
+			owner, err := c.OwnerOf("tokenID")
+			assert.NoError(t, err)
+			assert.Equal(t, "owner2", owner)
+		},
+	},
+	"unauthorized transfer": {
+		Caller:        "owner2",
+		Input:         `{"method": "transfer", "params": {"from": "owner", "to": "owner2", "tokenId": "tokenID"}}`,
+		ExpectedError: ErrUnauthorized,
+	},
+	"burn": {
+		Caller: "owner",
+		Input:  `{"method": "burn", "params": {"tokenId": "tokenID"}}`,
+		Check: func(t *testing.T, c *DefaultContract) {
+			_, err := c.OwnerOf("tokenID")
+			assert.Equal(t, ErrNoExist, err)
+		},
+	},
+	"approve": {
+		Caller: "owner",
+		Input:  `{"method": "approve", "params": {"owner": "owner", "approved": "approved2", "tokenId": "tokenID"}}`,
+		Check: func(t *testing.T, c *DefaultContract) {
+			approved, err := c.GetApproved("tokenID")
+			assert.NoError(t, err)
+			assert.Equal(t, "approved2", approved)
+		},
+	},
+	"set approval for all": {
+		Caller: "owner",
+		Input:  `{"method": "setApprovalForAll", "params": {"owner": "owner", "operator": "operator", "approved": true}}`,
+		Check: func(t *testing.T, c *DefaultContract) {
+			ok, err := c.IsApprovedForAll("owner", "operator")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		},
+	},
+	"transaction payload": {
+		Input:          `{"header": {"txn_id": "txnID"}, "payload": {"method": "ownerOf", "params": {"tokenId": "tokenID"}}}`,
+		ExpectedResult: "owner",
+	},
+	"custom method": {
+		Input:          `{"method": "tokenURI", "params": {"tokenId": "tokenID"}}`,
+		ExpectedResult: "uri",
+	},
+	"unknown method": {
+		Input:         `{"method": "selfDestruct"}`,
+		ExpectedError: ErrUnknownMethod,
+	},
+	"unknown param": {
+		Input:         `{"method": "ownerOf", "params": {"token": "tokenID"}}`,
+		ExpectedError: ErrInvalidRequest,
+	},
+	"invalid json": {
+		Input:         `{"method":`,
+		ExpectedError: ErrInvalidRequest,
+	},
+}
+
+func TestDispatcher(t *testing.T) {
+	for name, test := range dispatcherTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.SetCaller(test.Caller)
+			d := NewDispatcher()
+			d.Register("tokenURI", func(params json.RawMessage, contract Contract) (interface{}, error) {
+				var p struct {
+					TokenID string `json:"tokenId"`
+				}
+				if err := json.Unmarshal(params, &p); err != nil {
+					return nil, err
+				}
+				return contract.(*DefaultContract).TokenURI(p.TokenID)
+			})
+			result, err := d.HandleRPC([]byte(test.Input), contract)
+			assert.True(t, errors.Is(err, test.ExpectedError), "expected %v, got %v", test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				return
+			}
+			if test.Check != nil {
+				assert.Equal(t, contract, result)
+				test.Check(t, contract)
+				return
+			}
+			assert.Equal(t, test.ExpectedResult, result)
+		})
+	}
+}
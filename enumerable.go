@@ -0,0 +1,126 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+// ErrIndexOutOfRange is returned when a token is requested by an index that is negative
+// or not less than the number of tokens being indexed.
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// TokenByIndex returns the token at position i of the list of every token in existence.
+// Valid indexes range from 0 up to, but not including, TotalSupply. The order of tokens
+// is not guaranteed to be stable across burns.
+func (c *DefaultContract) TokenByIndex(i *big.Int) (string, error) {
+	tokens, err := c.AllTokens()
+	if err != nil {
+		return "", err
+	}
+	return tokenAt(tokens, i)
+}
+
+// TokenOfOwnerByIndex returns the token at position i of the list of tokens owned by owner.
+// Valid indexes range from 0 up to, but not including, BalanceOf(owner).
+func (c *DefaultContract) TokenOfOwnerByIndex(owner string, i *big.Int) (string, error) {
+	tokens, err := c.TokensOwnedBy(owner)
+	if err != nil && err != ErrNoExist {
+		return "", err
+	}
+	return tokenAt(tokens, i)
+}
+
+// AllTokens returns the ids of every token in existence.
+func (c *DefaultContract) AllTokens() ([]string, error) {
+	if c.TokenList == nil {
+		if err := c.fetchTokenList(); err != nil {
+			return nil, err
+		}
+	}
+	return c.TokenList, nil
+}
+
+func tokenAt(tokens []string, i *big.Int) (string, error) {
+	if i.Sign() < 0 || !i.IsUint64() || i.Uint64() >= uint64(len(tokens)) {
+		return "", ErrIndexOutOfRange
+	}
+	return tokens[i.Uint64()], nil
+}
+
+// loadTokenList fetches the global token list and its index from the heap if they have not
+// been loaded yet.
+func (c *DefaultContract) loadTokenList() error {
+	if c.TokenList == nil {
+		if err := c.fetchTokenList(); err != nil {
+			return err
+		}
+	}
+	if c.TokenListIndex == nil {
+		if err := c.fetchTokenListIndex(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addToTokenList appends tid to the global token list. The token list must be loaded.
+func (c *DefaultContract) addToTokenList(tid string) {
+	c.TokenListIndex[tid] = uint64(len(c.TokenList))
+	c.TokenList = append(c.TokenList, tid)
+}
+
+// removeFromTokenList removes tid from the global token list by moving the last token into
+// its slot. Tokens minted before the list existed are not indexed and are ignored.
+// The token list must be loaded.
+func (c *DefaultContract) removeFromTokenList(tid string) {
+	tokenIndex, ok := c.TokenListIndex[tid]
+	if !ok {
+		return
+	}
+	lastIndex := uint64(len(c.TokenList) - 1)
+	if tokenIndex != lastIndex {
+		lastToken := c.TokenList[lastIndex]
+		c.TokenList[tokenIndex] = lastToken
+		c.TokenListIndex[lastToken] = tokenIndex
+	}
+	c.TokenList = c.TokenList[:lastIndex]
+	delete(c.TokenListIndex, tid)
+}
+
+func (c *DefaultContract) fetchTokenList() error {
+	resp, err := c.GetDragonObject("allTokens")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenList = []string{}
+		return nil
+	}
+	var l []string
+	if err = json.Unmarshal(resp, &l); err != nil {
+		return err
+	}
+	if l == nil {
+		l = []string{}
+	}
+	c.TokenList = l
+	return nil
+}
+
+func (c *DefaultContract) fetchTokenListIndex() error {
+	resp, err := c.GetDragonObject("allTokensIndex")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenListIndex = make(map[string]uint64)
+		return nil
+	}
+	var m map[string]uint64
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenListIndex = m
+	return nil
+}
@@ -0,0 +1,182 @@
+package nft
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tokenByIndexTests = map[string]struct {
+		DCResponse    *dcResp
+		DefaultState  []string
+		Index         *big.Int
+		ExpectedToken string
+		ExpectedError error
+	}{
+		"fetch": {
+			DCResponse: &dcResp{
+				Response: `["tokenID", "tokenID2"]`,
+			},
+			Index:         bigOne,
+			ExpectedToken: "tokenID2",
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			Index:         BigZero,
+			ExpectedError: errFailed,
+		},
+		"token exists in memory": {
+			DefaultState:  []string{"tokenID", "tokenID2"},
+			Index:         BigZero,
+			ExpectedToken: "tokenID",
+		},
+		"index too large": {
+			DefaultState:  []string{"tokenID", "tokenID2"},
+			Index:         big.NewInt(2),
+			ExpectedError: ErrIndexOutOfRange,
+		},
+		"index negative": {
+			DefaultState:  []string{"tokenID"},
+			Index:         big.NewInt(-1),
+			ExpectedError: ErrIndexOutOfRange,
+		},
+		"no tokens": {
+			DCResponse:    &dcResp{},
+			Index:         BigZero,
+			ExpectedError: ErrIndexOutOfRange,
+		},
+	}
+
+	tokenOfOwnerByIndexTests = map[string]struct {
+		DefaultState  map[string][]string
+		Owner         string
+		Index         *big.Int
+		ExpectedToken string
+		ExpectedError error
+	}{
+		"token found": {
+			DefaultState:  map[string][]string{"owner": {"tokenID", "tokenID2"}},
+			Owner:         "owner",
+			Index:         bigOne,
+			ExpectedToken: "tokenID2",
+		},
+		"index too large": {
+			DefaultState:  map[string][]string{"owner": {"tokenID", "tokenID2"}},
+			Owner:         "owner",
+			Index:         big.NewInt(2),
+			ExpectedError: ErrIndexOutOfRange,
+		},
+		"owner has no tokens": {
+			DefaultState:  map[string][]string{"owner": {"tokenID"}},
+			Owner:         "owner2",
+			Index:         BigZero,
+			ExpectedError: ErrIndexOutOfRange,
+		},
+	}
+)
+
+func TestDefaultContract_TokenByIndex(t *testing.T) {
+	for name, test := range tokenByIndexTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenList = test.DefaultState
+			on := test.DCResponse != nil
+			var ret *dragonchain.Response
+			if on {
+				if test.DCResponse.Error == nil {
+					ret = &dragonchain.Response{
+						OK:       true,
+						Status:   http.StatusOK,
+						Response: []byte(test.DCResponse.Response),
+					}
+				}
+				mockClient.On("GetSmartContractObject", "allTokens", "").Once().Return(ret, test.DCResponse.Error)
+			}
+			token, err := contract.TokenByIndex(test.Index)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedToken, token)
+			if !on {
+				mockClient.AssertNotCalled(t, "GetSmartContractObject", "allTokens", "")
+			}
+		})
+	}
+}
+
+func TestDefaultContract_TokenOfOwnerByIndex(t *testing.T) {
+	for name, test := range tokenOfOwnerByIndexTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.OwnedTokens = test.DefaultState
+			token, err := contract.TokenOfOwnerByIndex(test.Owner, test.Index)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedToken, token)
+		})
+	}
+}
+
+func TestDefaultContract_EnumerationFollowsSupply(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TotalTokens = "0"
+	contract.ContractMinter = "minter"
+	contract.SetCaller("minter")
+	for _, tokenID := range []string{"tokenID", "tokenID2", "tokenID3"} {
+		assert.NoError(t, contract.Mint("owner", tokenID))
+	}
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Burn("tokenID"))
+
+	supply, err := contract.TotalSupply()
+	assert.NoError(t, err)
+	tokens, err := contract.AllTokens()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tokenID2", "tokenID3"}, tokens)
+	for i := int64(0); i < supply.Int64(); i++ {
+		token, err := contract.TokenByIndex(big.NewInt(i))
+		assert.NoError(t, err)
+		assert.Equal(t, tokens[i], token)
+		token, err = contract.TokenOfOwnerByIndex("owner", big.NewInt(i))
+		assert.NoError(t, err)
+		assert.Contains(t, tokens, token)
+	}
+	_, err = contract.TokenByIndex(supply)
+	assert.Equal(t, ErrIndexOutOfRange, err)
+}
+
+func TestDefaultContract_EnumerationFollowsSupplyAcrossInvocations(t *testing.T) {
+	heap := memoryHeap{}
+	// invoke runs op on a contract freshly loaded from the heap and persists the result.
+	invoke := func(caller string, op func(c *DefaultContract) error) {
+		contract := NewDefaultContract("test", "TEST", heap)
+		contract.ContractMinter = "minter"
+		contract.SetCaller(caller)
+		assert.NoError(t, op(contract))
+		heap.persist(t, contract)
+	}
+	invoke("minter", func(c *DefaultContract) error { return c.Mint("owner", "1") })
+	invoke("minter", func(c *DefaultContract) error { return c.Mint("owner", "2") })
+	invoke("owner", func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "1") })
+	invoke("owner", func(c *DefaultContract) error { return c.Burn("2") })
+
+	contract := NewDefaultContract("test", "TEST", heap)
+	supply, err := contract.TotalSupply()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", supply.String())
+	tokens, err := contract.AllTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, tokens)
+	token, err := contract.TokenByIndex(BigZero)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", token)
+	_, err = contract.TokenByIndex(supply)
+	assert.Equal(t, ErrIndexOutOfRange, err)
+}
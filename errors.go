@@ -0,0 +1,153 @@
+package nft
+
+import "errors"
+
+var (
+	// ErrUnknownMethod is returned by a Dispatcher for RPCs whose method is not registered.
+	ErrUnknownMethod = errors.New("unknown method")
+	// ErrInvalidRequest is returned for RPCs that are not valid requests or whose params do
+	// not match their method.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrInputTooLarge is returned by the MaxInputSize middleware for RPCs whose input is
+	// too large.
+	ErrInputTooLarge = errors.New("input too large")
+)
+
+// ErrorCode is a stable, machine-readable identifier for a class of failure. Unlike error
+// messages, codes never change once released.
+type ErrorCode string
+
+// The error codes of the package's errors, plus the codes used by the Runtime for failures
+// that are not caused by the contract.
+const (
+	CodeInternal            ErrorCode = "internal"
+	CodeInvalidConfig       ErrorCode = "invalid_config"
+	CodeInvalidRequest      ErrorCode = "invalid_request"
+	CodeUnknownMethod       ErrorCode = "unknown_method"
+	CodeInputTooLarge       ErrorCode = "input_too_large"
+	CodeNotFound            ErrorCode = "not_found"
+	CodeAlreadyExists       ErrorCode = "already_exists"
+	CodeInvalidBigInt       ErrorCode = "invalid_big_int"
+	CodeNotOwner            ErrorCode = "not_owner"
+	CodeUnauthorized        ErrorCode = "unauthorized"
+	CodeIndexOutOfRange     ErrorCode = "index_out_of_range"
+	CodeInvalidAttribute    ErrorCode = "invalid_attribute"
+	CodeTransferRejected    ErrorCode = "transfer_rejected"
+	CodeDuplicateToken      ErrorCode = "duplicate_token"
+	CodeNotSupported        ErrorCode = "not_supported"
+	CodeNotUnique           ErrorCode = "not_unique"
+	CodeInvalidAmount       ErrorCode = "invalid_amount"
+	CodeInsufficientBalance ErrorCode = "insufficient_balance"
+	CodeLengthMismatch      ErrorCode = "length_mismatch"
+	CodeInvalidRoyalty      ErrorCode = "invalid_royalty"
+	CodePaused              ErrorCode = "paused"
+	CodeMaxSupplyReached    ErrorCode = "max_supply_reached"
+	CodeMintLimitExceeded   ErrorCode = "mint_limit_exceeded"
+	CodeSoulbound           ErrorCode = "soulbound"
+	CodeInvalidSignature    ErrorCode = "invalid_signature"
+	CodeVoucherExpired      ErrorCode = "voucher_expired"
+	CodeVoucherRedeemed     ErrorCode = "voucher_redeemed"
+	CodeNotAllowlisted      ErrorCode = "not_allowlisted"
+)
+
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrUnknownMethod, CodeUnknownMethod},
+	{ErrInvalidRequest, CodeInvalidRequest},
+	{ErrInputTooLarge, CodeInputTooLarge},
+	{ErrNoExist, CodeNotFound},
+	{ErrAlreadyExists, CodeAlreadyExists},
+	{ErrInvalidBigIntString, CodeInvalidBigInt},
+	{ErrNotOwner, CodeNotOwner},
+	{ErrUnauthorized, CodeUnauthorized},
+	{ErrIndexOutOfRange, CodeIndexOutOfRange},
+	{ErrInvalidAttribute, CodeInvalidAttribute},
+	{ErrTransferRejected, CodeTransferRejected},
+	{ErrDuplicateToken, CodeDuplicateToken},
+	{ErrNotSupported, CodeNotSupported},
+	{ErrNotUnique, CodeNotUnique},
+	{ErrInvalidAmount, CodeInvalidAmount},
+	{ErrInsufficientBalance, CodeInsufficientBalance},
+	{ErrLengthMismatch, CodeLengthMismatch},
+	{ErrInvalidRoyalty, CodeInvalidRoyalty},
+	{ErrPaused, CodePaused},
+	{ErrMaxSupplyReached, CodeMaxSupplyReached},
+	{ErrMintLimitExceeded, CodeMintLimitExceeded},
+	{ErrSoulbound, CodeSoulbound},
+	{ErrInvalidSignature, CodeInvalidSignature},
+	{ErrVoucherExpired, CodeVoucherExpired},
+	{ErrVoucherRedeemed, CodeVoucherRedeemed},
+	{ErrNotAllowlisted, CodeNotAllowlisted},
+}
+
+// The exit codes used by the Runtime, one per class of failure.
+const (
+	// ExitInternal means something unexpected failed, such as reading the heap.
+	ExitInternal = 1
+	// ExitInvalidConfig means the contract is not configured correctly.
+	ExitInvalidConfig = 2
+	// ExitInvalidRequest means the input could not be understood.
+	ExitInvalidRequest = 3
+	// ExitRejected means the contract understood the request but refused it.
+	ExitRejected = 4
+)
+
+// Error is a failure with a stable ErrorCode. Handlers can return an Error to give their own
+// failures a code.
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// Error returns the error's message.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the error's code.
+func (e *Error) ErrorCode() ErrorCode {
+	return e.Code
+}
+
+// ErrorResponse is written by the Runtime in place of the heap output when an invocation
+// fails:
+//
+//	{"error": {"code": "not_found", "message": "failed to handle RPC: resource does not exist"}}
+type ErrorResponse struct {
+	Error *Error `json:"error"`
+}
+
+// NewErrorResponse returns the ErrorResponse for err.
+func NewErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{Error: &Error{Code: CodeOf(err), Message: err.Error()}}
+}
+
+// CodeOf returns the ErrorCode of err. Errors that are, or wrap, one of the package's errors
+// or an error with an ErrorCode method get that code. Any other error is CodeInternal.
+func CodeOf(err error) ErrorCode {
+	var coder interface{ ErrorCode() ErrorCode }
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeInternal
+}
+
+// ExitCodeOf returns the exit code of the class of failure that code belongs to.
+func ExitCodeOf(code ErrorCode) int {
+	switch code {
+	case CodeInternal:
+		return ExitInternal
+	case CodeInvalidConfig:
+		return ExitInvalidConfig
+	case CodeInvalidRequest, CodeUnknownMethod, CodeInputTooLarge:
+		return ExitInvalidRequest
+	}
+	return ExitRejected
+}
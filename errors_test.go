@@ -0,0 +1,86 @@
+package nft
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var codeOfTests = map[string]struct {
+	Err          error
+	ExpectedCode ErrorCode
+	ExpectedExit int
+}{
+	"package error": {
+		Err:          ErrNoExist,
+		ExpectedCode: CodeNotFound,
+		ExpectedExit: ExitRejected,
+	},
+	"wrapped package error": {
+		Err:          fmt.Errorf("failed to handle RPC: %w", ErrAlreadyExists),
+		ExpectedCode: CodeAlreadyExists,
+		ExpectedExit: ExitRejected,
+	},
+	"rejected transfer": {
+		Err:          fmt.Errorf("%w: %s", ErrTransferRejected, errFailed),
+		ExpectedCode: CodeTransferRejected,
+		ExpectedExit: ExitRejected,
+	},
+	"invalid big int": {
+		Err:          ErrInvalidBigIntString,
+		ExpectedCode: CodeInvalidBigInt,
+		ExpectedExit: ExitRejected,
+	},
+	"invalid request": {
+		Err:          fmt.Errorf("%w: unexpected end of JSON input", ErrInvalidRequest),
+		ExpectedCode: CodeInvalidRequest,
+		ExpectedExit: ExitInvalidRequest,
+	},
+	"unknown method": {
+		Err:          ErrUnknownMethod,
+		ExpectedCode: CodeUnknownMethod,
+		ExpectedExit: ExitInvalidRequest,
+	},
+	"coded error": {
+		Err:          fmt.Errorf("failed to handle RPC: %w", &Error{Code: "sold_out", Message: "sold out"}),
+		ExpectedCode: "sold_out",
+		ExpectedExit: ExitRejected,
+	},
+	"config error": {
+		Err:          &Error{Code: CodeInvalidConfig, Message: "no name provided for contract"},
+		ExpectedCode: CodeInvalidConfig,
+		ExpectedExit: ExitInvalidConfig,
+	},
+	"unknown error": {
+		Err:          errFailed,
+		ExpectedCode: CodeInternal,
+		ExpectedExit: ExitInternal,
+	},
+}
+
+func TestCodeOf(t *testing.T) {
+	for name, test := range codeOfTests {
+		t.Run(name, func(t *testing.T) {
+			code := CodeOf(test.Err)
+			assert.Equal(t, test.ExpectedCode, code)
+			assert.Equal(t, test.ExpectedExit, ExitCodeOf(code))
+		})
+	}
+}
+
+func TestErrorCodesAreUnique(t *testing.T) {
+	seen := make(map[ErrorCode]error)
+	for _, c := range errorCodes {
+		assert.NotContains(t, seen, c.code)
+		seen[c.code] = c.err
+		assert.Equal(t, c.code, CodeOf(c.err))
+	}
+}
+
+func TestNewErrorResponse(t *testing.T) {
+	b, err := json.Marshal(NewErrorResponse(fmt.Errorf("failed to handle RPC: %w", ErrNoExist)))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"error": {"code": "not_found", "message": "failed to handle RPC: resource does not exist"}}`, string(b))
+}
@@ -0,0 +1,43 @@
+package nft
+
+// EventType identifies the kind of state change an Event records.
+type EventType string
+
+// The events recorded by DefaultContract.
+const (
+	EventMint           EventType = "mint"
+	EventBurn           EventType = "burn"
+	EventTransfer       EventType = "transfer"
+	EventApproval       EventType = "approval"
+	EventApprovalForAll EventType = "approvalForAll"
+	EventUser           EventType = "user"
+)
+
+// Event records a single state change made during an invocation so that off-chain indexers
+// can follow the activity of a contract.
+//
+// Caller is the address that made the change. For mints, burns and transfers, From and To
+// are the previous and new owners of the token; From is empty for mints and To is empty for
+// burns. For approvals, From is the token's owner and To is the approved address, or "" if
+// the approval was cleared. For operator approvals, From is the owner, To is the operator and
+// Approved tells whether the operator was approved or revoked. For rentals, To is the new
+// user of the token, or "" if the rental ended, and Expires is when the rental expires.
+type Event struct {
+	Type     EventType `json:"type"`
+	Caller   string    `json:"caller,omitempty"`
+	From     string    `json:"from,omitempty"`
+	To       string    `json:"to,omitempty"`
+	TokenID  string    `json:"tokenId,omitempty"`
+	Approved bool      `json:"approved"`
+	Expires  uint64    `json:"expires,omitempty"`
+}
+
+// Events returns the events recorded by the contract since it was created, in order.
+func (c *DefaultContract) Events() []Event {
+	return c.events
+}
+
+func (c *DefaultContract) emit(e Event) {
+	e.Caller = c.caller
+	c.events = append(c.events, e)
+}
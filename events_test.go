@@ -0,0 +1,89 @@
+package nft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var eventTests = map[string]struct {
+	Caller         string
+	Operation      func(c *DefaultContract) error
+	ExpectedEvents []Event
+}{
+	"mint": {
+		Caller:    "minter",
+		Operation: func(c *DefaultContract) error { return c.Mint("owner2", "tokenID4") },
+		ExpectedEvents: []Event{
+			{Type: EventMint, Caller: "minter", To: "owner2", TokenID: "tokenID4"},
+		},
+	},
+	"mint batch": {
+		Caller: "minter",
+		Operation: func(c *DefaultContract) error {
+			return c.MintBatch([]TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner2", TokenID: "tokenID5"}})
+		},
+		ExpectedEvents: []Event{
+			{Type: EventMint, Caller: "minter", To: "owner", TokenID: "tokenID4"},
+			{Type: EventMint, Caller: "minter", To: "owner2", TokenID: "tokenID5"},
+		},
+	},
+	"burn": {
+		Caller:    "owner",
+		Operation: func(c *DefaultContract) error { return c.Burn("tokenID") },
+		ExpectedEvents: []Event{
+			{Type: EventBurn, Caller: "owner", From: "owner", TokenID: "tokenID"},
+		},
+	},
+	"transfer": {
+		Caller:    "approved",
+		Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID2") },
+		ExpectedEvents: []Event{
+			{Type: EventTransfer, Caller: "approved", From: "owner", To: "owner2", TokenID: "tokenID2"},
+		},
+	},
+	"approve": {
+		Caller:    "owner",
+		Operation: func(c *DefaultContract) error { return c.Approve("owner", "approved2", "tokenID") },
+		ExpectedEvents: []Event{
+			{Type: EventApproval, Caller: "owner", From: "owner", To: "approved2", TokenID: "tokenID"},
+		},
+	},
+	"clear approval": {
+		Caller:    "owner",
+		Operation: func(c *DefaultContract) error { return c.Approve("owner", "", "tokenID2") },
+		ExpectedEvents: []Event{
+			{Type: EventApproval, Caller: "owner", From: "owner", TokenID: "tokenID2"},
+		},
+	},
+	"set approval for all": {
+		Caller:    "owner",
+		Operation: func(c *DefaultContract) error { return c.SetApprovalForAll("owner", "operator", true) },
+		ExpectedEvents: []Event{
+			{Type: EventApprovalForAll, Caller: "owner", From: "owner", To: "operator", Approved: true},
+		},
+	},
+	"failed transfer": {
+		Caller:    "owner2",
+		Operation: func(c *DefaultContract) error { c.Transfer("owner", "owner2", "tokenID"); return nil },
+	},
+	"failed batch": {
+		Caller: "minter",
+		Operation: func(c *DefaultContract) error {
+			c.MintBatch([]TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner", TokenID: "tokenID"}})
+			return nil
+		},
+	},
+}
+
+func TestDefaultContract_Events(t *testing.T) {
+	for name, test := range eventTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.events = nil
+			contract.SetCaller(test.Caller)
+			assert.NoError(t, test.Operation(contract))
+			assert.Equal(t, test.ExpectedEvents, contract.Events())
+		})
+	}
+}
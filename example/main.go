@@ -1,6 +1,10 @@
 package main
 
 import (
+	"encoding/json"
+	"log"
+	"os"
+
 	"github.com/summerplaygames/nft"
 )
 
@@ -14,12 +18,28 @@ var (
 
 func main() {
 	contractFactory := &nft.DefaultContractFactory{}
-	rt := nft.NewRuntime(handleRPC(), contractFactory)
+	logger := log.New(os.Stderr, "nft: ", 0)
+	rt := nft.NewRuntime(dispatcher(), contractFactory,
+		nft.Recover(),
+		nft.Logging(logger),
+		nft.MaxInputSize(64<<10),
+	)
 	rt.Run()
 }
 
-func handleRPC() nft.RPCHandlerFunc {
-	return func(rpc []byte, contract nft.Contract) (interface{}, error) {
-		return contract, nil
-	}
+func dispatcher() *nft.Dispatcher {
+	d := nft.NewDispatcher()
+	d.Register("tokenURI", func(params json.RawMessage, contract nft.Contract) (interface{}, error) {
+		var p struct {
+			TokenID string `json:"tokenId"`
+		}
+		if err := nft.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if concrete, ok := contract.(*nft.DefaultContract); ok {
+			return concrete.TokenURI(p.TokenID)
+		}
+		return nil, nft.ErrNotSupported
+	})
+	return d
 }
@@ -0,0 +1,89 @@
+package nft
+
+import "encoding/json"
+
+// Provenance is a single entry in the chain of custody of a token. Action is EventMint,
+// EventTransfer or EventBurn; From is empty for mints and To is empty for burns.
+type Provenance struct {
+	Action       EventType `json:"action"`
+	From         string    `json:"from,omitempty"`
+	To           string    `json:"to,omitempty"`
+	InvocationID string    `json:"invocationId,omitempty"`
+}
+
+// HistoryOf returns a page of the provenance of a token, oldest entry first. The page starts
+// at entry offset and holds at most limit entries; a limit of zero returns every entry from
+// offset on. Pages past the end of the history are empty. The history of a burned token is
+// still available. ErrNoExist is returned if the token has never been minted.
+func (c *DefaultContract) HistoryOf(tokenID string, offset, limit uint64) ([]Provenance, error) {
+	if c.TokenHistory == nil {
+		if err := c.fetchTokenHistory(); err != nil {
+			return nil, err
+		}
+	}
+	history, ok := c.TokenHistory[tokenID]
+	if !ok {
+		return nil, ErrNoExist
+	}
+	n := uint64(len(history))
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if limit > 0 && limit < n-offset {
+		end = offset + limit
+	}
+	page := make([]Provenance, end-offset)
+	copy(page, history[offset:end])
+	return page, nil
+}
+
+// HistoryLength returns the number of entries in the provenance of a token.
+func (c *DefaultContract) HistoryLength(tokenID string) (uint64, error) {
+	if c.TokenHistory == nil {
+		if err := c.fetchTokenHistory(); err != nil {
+			return 0, err
+		}
+	}
+	history, ok := c.TokenHistory[tokenID]
+	if !ok {
+		return 0, ErrNoExist
+	}
+	return uint64(len(history)), nil
+}
+
+// loadHistory fetches the provenance log from the heap if it has not been loaded yet.
+func (c *DefaultContract) loadHistory() error {
+	if c.TokenHistory == nil {
+		return c.fetchTokenHistory()
+	}
+	return nil
+}
+
+// recordProvenance appends an entry to the provenance of a token. The provenance log must
+// be loaded.
+func (c *DefaultContract) recordProvenance(tokenID string, action EventType, from, to string) {
+	c.TokenHistory[tokenID] = append(c.TokenHistory[tokenID], Provenance{
+		Action:       action,
+		From:         from,
+		To:           to,
+		InvocationID: c.invocationID,
+	})
+}
+
+func (c *DefaultContract) fetchTokenHistory() error {
+	resp, err := c.GetDragonObject("tokenHistory")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenHistory = make(map[string][]Provenance)
+		return nil
+	}
+	var m map[string][]Provenance
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenHistory = m
+	return nil
+}
@@ -0,0 +1,144 @@
+package nft
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	historyOfTests = map[string]struct {
+		TokenID         string
+		Offset          uint64
+		Limit           uint64
+		ExpectedHistory []Provenance
+		ExpectedError   error
+	}{
+		"full history": {
+			TokenID: "tokenID",
+			ExpectedHistory: []Provenance{
+				{Action: EventMint, To: "owner", InvocationID: "txn1"},
+				{Action: EventTransfer, From: "owner", To: "owner2", InvocationID: "txn2"},
+				{Action: EventTransfer, From: "owner2", To: "owner3", InvocationID: "txn3"},
+				{Action: EventBurn, From: "owner3", InvocationID: "txn4"},
+			},
+		},
+		"first page": {
+			TokenID: "tokenID",
+			Limit:   2,
+			ExpectedHistory: []Provenance{
+				{Action: EventMint, To: "owner", InvocationID: "txn1"},
+				{Action: EventTransfer, From: "owner", To: "owner2", InvocationID: "txn2"},
+			},
+		},
+		"last page": {
+			TokenID: "tokenID",
+			Offset:  3,
+			Limit:   2,
+			ExpectedHistory: []Provenance{
+				{Action: EventBurn, From: "owner3", InvocationID: "txn4"},
+			},
+		},
+		"past the end": {
+			TokenID:         "tokenID",
+			Offset:          10,
+			Limit:           2,
+			ExpectedHistory: []Provenance{},
+		},
+		"never minted": {
+			TokenID:       "tokenID2",
+			ExpectedError: ErrNoExist,
+		},
+	}
+
+	fetchHistoryTests = map[string]struct {
+		DCResponse      *dcResp
+		ExpectedHistory []Provenance
+		ExpectedError   error
+	}{
+		"fetch history": {
+			DCResponse: &dcResp{
+				Response: `{"tokenID":[{"action":"mint","to":"owner","invocationId":"txn1"}]}`,
+			},
+			ExpectedHistory: []Provenance{{Action: EventMint, To: "owner", InvocationID: "txn1"}},
+		},
+		"not in heap": {
+			DCResponse:    &dcResp{},
+			ExpectedError: ErrNoExist,
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			ExpectedError: errFailed,
+		},
+	}
+)
+
+func TestDefaultContract_HistoryOf(t *testing.T) {
+	for name, test := range historyOfTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TotalTokens = "0"
+			contract.ContractMinter = "minter"
+			steps := []struct {
+				Caller    string
+				Operation func() error
+			}{
+				{"minter", func() error { return contract.Mint("owner", "tokenID") }},
+				{"owner", func() error { return contract.Transfer("owner", "owner2", "tokenID") }},
+				{"owner2", func() error { return contract.SafeTransfer("owner2", "owner3", "tokenID", nil) }},
+				{"owner3", func() error { return contract.Burn("tokenID") }},
+			}
+			for i, step := range steps {
+				contract.SetCaller(step.Caller)
+				contract.SetInvocationID("txn" + strconv.Itoa(i+1))
+				assert.NoError(t, step.Operation())
+			}
+			// A failed operation leaves no trace.
+			contract.SetCaller("owner")
+			assert.Error(t, contract.Transfer("owner", "owner2", "tokenID"))
+
+			history, err := contract.HistoryOf(test.TokenID, test.Offset, test.Limit)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedHistory, history)
+		})
+	}
+}
+
+func TestDefaultContract_FetchHistory(t *testing.T) {
+	for name, test := range fetchHistoryTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			var ret *dragonchain.Response
+			if test.DCResponse.Error == nil {
+				ret = &dragonchain.Response{
+					OK:       true,
+					Status:   http.StatusOK,
+					Response: []byte(test.DCResponse.Response),
+				}
+			}
+			mockClient.On("GetSmartContractObject", "tokenHistory", "").Once().Return(ret, test.DCResponse.Error)
+			history, err := contract.HistoryOf("tokenID", 0, 0)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedHistory, history)
+		})
+	}
+}
+
+func TestDefaultContract_HistoryLength(t *testing.T) {
+	contract := newBatchTestContract()
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Transfer("owner", "owner2", "tokenID"))
+	n, err := contract.HistoryLength("tokenID")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), n)
+	_, err = contract.HistoryLength("tokenID4")
+	assert.Equal(t, ErrNoExist, err)
+}
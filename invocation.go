@@ -0,0 +1,75 @@
+package nft
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Invocation describes the Dragonchain transaction that invoked a contract.
+type Invocation struct {
+	// TxnID is the id of the transaction.
+	TxnID string `json:"txn_id"`
+	// TxnType is the transaction type the contract is registered under.
+	TxnType string `json:"txn_type"`
+	// Tag is the transaction's searchable tag.
+	Tag string `json:"tag,omitempty"`
+	// Timestamp is the time of the transaction as a unix timestamp in seconds.
+	Timestamp uint64 `json:"timestamp"`
+	// Invoker is the address that invoked the contract.
+	Invoker string `json:"invoker,omitempty"`
+	// Payload is the raw payload of the transaction, usually the RPC to handle.
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// ParseInvocation parses a Dragonchain transaction of the form
+//   {"header": {"txn_id": "...", "txn_type": "...", "tag": "...", "timestamp": "...", "invoker": "..."},
+//    "payload": ...}
+// Input without a header is treated as the payload of an anonymous invocation, which is handy
+// when running a contract locally. ErrInvalidRequest is returned if input is not valid JSON or
+// the header is malformed.
+func ParseInvocation(input []byte) (Invocation, error) {
+	var txn struct {
+		Header *struct {
+			TxnID     string `json:"txn_id"`
+			TxnType   string `json:"txn_type"`
+			Tag       string `json:"tag"`
+			Timestamp string `json:"timestamp"`
+			Invoker   string `json:"invoker"`
+		} `json:"header"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(input, &txn); err != nil {
+		if !json.Valid(input) {
+			return Invocation{}, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+		}
+		// Valid JSON that is not an object, such as an array, cannot carry a header.
+		return Invocation{Payload: json.RawMessage(input)}, nil
+	}
+	if txn.Header == nil {
+		return Invocation{Payload: json.RawMessage(input)}, nil
+	}
+	inv := Invocation{
+		TxnID:   txn.Header.TxnID,
+		TxnType: txn.Header.TxnType,
+		Tag:     txn.Header.Tag,
+		Invoker: txn.Header.Invoker,
+		Payload: txn.Payload,
+	}
+	if txn.Header.Timestamp != "" {
+		timestamp, err := strconv.ParseUint(txn.Header.Timestamp, 10, 64)
+		if err != nil {
+			return Invocation{}, fmt.Errorf("%w: invalid timestamp %q", ErrInvalidRequest, txn.Header.Timestamp)
+		}
+		inv.Timestamp = timestamp
+	}
+	return inv, nil
+}
+
+// SetInvocation sets the caller, invocation id and timestamp of the contract from the
+// transaction that invoked it.
+func (c *DefaultContract) SetInvocation(inv Invocation) {
+	c.SetCaller(inv.Invoker)
+	c.SetInvocationID(inv.TxnID)
+	c.SetTimestamp(inv.Timestamp)
+}
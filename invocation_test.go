@@ -0,0 +1,90 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parseInvocationTests = map[string]struct {
+	Input              string
+	ExpectedInvocation Invocation
+	ExpectedError      error
+}{
+	"transaction": {
+		Input: `{
+			"version": "1",
+			"header": {
+				"txn_type": "nft",
+				"dc_id": "dcID",
+				"txn_id": "txnID",
+				"block_id": "",
+				"tag": "mint",
+				"timestamp": "1573773125",
+				"invoker": "minter"
+			},
+			"payload": {"method": "mint", "params": {"to": "owner", "tokenId": "tokenID"}}
+		}`,
+		ExpectedInvocation: Invocation{
+			TxnID:     "txnID",
+			TxnType:   "nft",
+			Tag:       "mint",
+			Timestamp: 1573773125,
+			Invoker:   "minter",
+			Payload:   json.RawMessage(`{"method": "mint", "params": {"to": "owner", "tokenId": "tokenID"}}`),
+		},
+	},
+	"no timestamp": {
+		Input: `{"header": {"txn_id": "txnID"}, "payload": "ping"}`,
+		ExpectedInvocation: Invocation{
+			TxnID:   "txnID",
+			Payload: json.RawMessage(`"ping"`),
+		},
+	},
+	"no header": {
+		Input: `{"method": "name"}`,
+		ExpectedInvocation: Invocation{
+			Payload: json.RawMessage(`{"method": "name"}`),
+		},
+	},
+	"not an object": {
+		Input: `["name"]`,
+		ExpectedInvocation: Invocation{
+			Payload: json.RawMessage(`["name"]`),
+		},
+	},
+	"invalid timestamp": {
+		Input:         `{"header": {"txn_id": "txnID", "timestamp": "yesterday"}}`,
+		ExpectedError: ErrInvalidRequest,
+	},
+	"invalid json": {
+		Input:         `{"header":`,
+		ExpectedError: ErrInvalidRequest,
+	},
+}
+
+func TestParseInvocation(t *testing.T) {
+	for name, test := range parseInvocationTests {
+		t.Run(name, func(t *testing.T) {
+			inv, err := ParseInvocation([]byte(test.Input))
+			assert.True(t, errors.Is(err, test.ExpectedError), "expected %v, got %v", test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedInvocation, inv)
+		})
+	}
+}
+
+func TestDefaultContract_SetInvocation(t *testing.T) {
+	contract := newBatchTestContract()
+	contract.SetInvocation(Invocation{TxnID: "txnID", Timestamp: 1000, Invoker: "owner"})
+	assert.Equal(t, "owner", contract.Caller())
+	assert.NoError(t, contract.SetUser("tokenID", "renter", 999))
+	user, err := contract.UserOf("tokenID")
+	assert.NoError(t, err)
+	assert.Equal(t, "", user)
+	assert.NoError(t, contract.Transfer("owner", "owner2", "tokenID"))
+	history, err := contract.HistoryOf("tokenID", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []Provenance{{Action: EventTransfer, From: "owner", To: "owner2", InvocationID: "txnID"}}, history)
+}
@@ -0,0 +1,99 @@
+package nft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// MerkleTree is a Merkle tree over a list of addresses. It is used off-chain to compute the
+// root stored by an allowlist and the proofs that addresses present when minting.
+//
+// Leaves are the sha256 hash of a 0x00 byte followed by the address. Each inner node is the
+// sha256 hash of a 0x01 byte followed by its two children in ascending byte order, so proofs
+// need no left/right flags. A node without a sibling is promoted to the next level unchanged.
+type MerkleTree struct {
+	levels [][][]byte
+	index  map[string]int
+}
+
+// NewMerkleTree builds a MerkleTree from a list of addresses. Duplicate addresses are ignored.
+func NewMerkleTree(addresses []string) *MerkleTree {
+	t := &MerkleTree{index: make(map[string]int, len(addresses))}
+	var leaves [][]byte
+	for _, address := range addresses {
+		if _, ok := t.index[address]; ok {
+			continue
+		}
+		t.index[address] = len(leaves)
+		leaves = append(leaves, merkleLeaf(address))
+	}
+	t.levels = [][][]byte{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, merkleNode(level[i], level[i+1]))
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t
+}
+
+// Root returns the hex-encoded root of the tree, or "" if the tree is empty.
+func (t *MerkleTree) Root() string {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		return ""
+	}
+	return hex.EncodeToString(top[0])
+}
+
+// Proof returns the hex-encoded proof that address is in the tree. ErrNoExist is returned if
+// it is not.
+func (t *MerkleTree) Proof(address string) ([]string, error) {
+	i, ok := t.index[address]
+	if !ok {
+		return nil, ErrNoExist
+	}
+	proof := []string{}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := i ^ 1
+		if sibling < len(level) {
+			proof = append(proof, hex.EncodeToString(level[sibling]))
+		}
+		i /= 2
+	}
+	return proof, nil
+}
+
+// VerifyMerkleProof reports whether proof shows that address is in the tree with the given
+// hex-encoded root.
+func VerifyMerkleProof(root, address string, proof []string) bool {
+	hash := merkleLeaf(address)
+	for _, p := range proof {
+		sibling, err := hex.DecodeString(p)
+		if err != nil || len(sibling) != sha256.Size {
+			return false
+		}
+		hash = merkleNode(hash, sibling)
+	}
+	return root != "" && hex.EncodeToString(hash) == root
+}
+
+func merkleLeaf(address string) []byte {
+	h := sha256.Sum256(append([]byte{0}, address...))
+	return h[:]
+}
+
+func merkleNode(a, b []byte) []byte {
+	pair := [][]byte{a, b}
+	sort.Slice(pair, func(i, j int) bool { return bytes.Compare(pair[i], pair[j]) < 0 })
+	h := sha256.Sum256(append(append([]byte{1}, pair[0]...), pair[1]...))
+	return h[:]
+}
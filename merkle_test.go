@@ -0,0 +1,50 @@
+package nft
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerkleTree(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 4, 5, 8, 13, 100} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			addresses := make([]string, n)
+			for i := range addresses {
+				addresses[i] = "address" + strconv.Itoa(i)
+			}
+			tree := NewMerkleTree(addresses)
+			root := tree.Root()
+			assert.NotEmpty(t, root)
+			for _, address := range addresses {
+				proof, err := tree.Proof(address)
+				assert.NoError(t, err)
+				assert.True(t, VerifyMerkleProof(root, address, proof), address)
+				assert.False(t, VerifyMerkleProof(root, address+"x", proof), address)
+			}
+			_, err := tree.Proof("stranger")
+			assert.Equal(t, ErrNoExist, err)
+		})
+	}
+}
+
+func TestMerkleTree_Duplicates(t *testing.T) {
+	tree := NewMerkleTree([]string{"a", "b", "a"})
+	assert.Equal(t, NewMerkleTree([]string{"a", "b"}).Root(), tree.Root())
+}
+
+func TestMerkleTree_Empty(t *testing.T) {
+	tree := NewMerkleTree(nil)
+	assert.Equal(t, "", tree.Root())
+	assert.False(t, VerifyMerkleProof("", "a", nil))
+}
+
+func TestVerifyMerkleProof_Invalid(t *testing.T) {
+	tree := NewMerkleTree([]string{"a", "b", "c"})
+	proof, err := tree.Proof("a")
+	assert.NoError(t, err)
+	assert.False(t, VerifyMerkleProof(tree.Root(), "a", proof[:1]))
+	assert.False(t, VerifyMerkleProof(tree.Root(), "a", append(proof, proof[0])))
+	assert.False(t, VerifyMerkleProof(tree.Root(), "a", []string{"zz"}))
+}
@@ -0,0 +1,101 @@
+package nft
+
+import "encoding/json"
+
+// TokenURI returns the metadata URI of a token. A URI set with SetTokenURI takes precedence;
+// otherwise the URI is the collection's base URI followed by the token id. If neither is set,
+// TokenURI returns "". ErrNoExist is returned if the token has not been minted.
+func (c *DefaultContract) TokenURI(tokenID string) (string, error) {
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return "", err
+	}
+	if c.TokenURIs == nil {
+		if err := c.fetchTokenURIs(); err != nil {
+			return "", err
+		}
+	}
+	if uri, ok := c.TokenURIs[tokenID]; ok {
+		return uri, nil
+	}
+	baseURI, err := c.BaseURI()
+	if err != nil || baseURI == "" {
+		return "", err
+	}
+	return baseURI + tokenID, nil
+}
+
+// BaseURI returns the base URI shared by every token in the collection.
+func (c *DefaultContract) BaseURI() (string, error) {
+	if c.BaseTokenURI == "" && !c.baseURILoaded {
+		if err := c.fetchBaseURI(); err != nil {
+			return "", err
+		}
+	}
+	return c.BaseTokenURI, nil
+}
+
+// SetBaseURI sets the base URI shared by every token in the collection. Only holders of
+// MinterRole may change it.
+func (c *DefaultContract) SetBaseURI(uri string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	c.BaseTokenURI = uri
+	c.baseURILoaded = true
+	return nil
+}
+
+// SetTokenURI overrides the metadata URI of a single token. Setting "" removes the override.
+// Only holders of MinterRole may set token URIs. The override is removed when the token is burned.
+func (c *DefaultContract) SetTokenURI(tokenID, uri string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return err
+	}
+	if c.TokenURIs == nil {
+		if err := c.fetchTokenURIs(); err != nil {
+			return err
+		}
+	}
+	if uri == "" {
+		delete(c.TokenURIs, tokenID)
+		return nil
+	}
+	c.TokenURIs[tokenID] = uri
+	return nil
+}
+
+func (c *DefaultContract) fetchBaseURI() error {
+	resp, err := c.GetDragonObject("baseURI")
+	if err != nil {
+		return err
+	}
+	c.BaseTokenURI = string(resp)
+	c.baseURILoaded = true
+	return nil
+}
+
+func (c *DefaultContract) fetchTokenURIs() error {
+	resp, err := c.GetDragonObject("tokenURIs")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenURIs = make(map[string]string)
+		return nil
+	}
+	var m map[string]string
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenURIs = m
+	return nil
+}
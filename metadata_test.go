@@ -0,0 +1,169 @@
+package nft
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tokenURITests = map[string]struct {
+		DCResponse    *dcResp
+		TokenURIs     map[string]string
+		BaseURI       string
+		TokenID       string
+		ExpectedURI   string
+		ExpectedError error
+	}{
+		"fetch base uri": {
+			DCResponse: &dcResp{
+				Response: "https://example.com/tokens/",
+			},
+			TokenURIs:   map[string]string{},
+			TokenID:     "tokenID",
+			ExpectedURI: "https://example.com/tokens/tokenID",
+		},
+		"fetch base uri error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			TokenURIs:     map[string]string{},
+			TokenID:       "tokenID",
+			ExpectedError: errFailed,
+		},
+		"base uri in memory": {
+			TokenURIs:   map[string]string{},
+			BaseURI:     "https://example.com/tokens/",
+			TokenID:     "tokenID",
+			ExpectedURI: "https://example.com/tokens/tokenID",
+		},
+		"token uri overrides base uri": {
+			TokenURIs:   map[string]string{"tokenID": "ipfs://token"},
+			BaseURI:     "https://example.com/tokens/",
+			TokenID:     "tokenID",
+			ExpectedURI: "ipfs://token",
+		},
+		"no uri": {
+			DCResponse: &dcResp{},
+			TokenURIs:  map[string]string{},
+			TokenID:    "tokenID",
+		},
+		"token no exist": {
+			TokenID:       "tokenID2",
+			ExpectedError: ErrNoExist,
+		},
+	}
+
+	setTokenURITests = map[string]struct {
+		Caller        string
+		TokenURIs     map[string]string
+		TokenID       string
+		URI           string
+		ExpectedURIs  map[string]string
+		ExpectedError error
+	}{
+		"uri set": {
+			Caller:       "minter",
+			TokenURIs:    map[string]string{},
+			TokenID:      "tokenID",
+			URI:          "ipfs://token",
+			ExpectedURIs: map[string]string{"tokenID": "ipfs://token"},
+		},
+		"uri cleared": {
+			Caller:       "minter",
+			TokenURIs:    map[string]string{"tokenID": "ipfs://token"},
+			TokenID:      "tokenID",
+			ExpectedURIs: map[string]string{},
+		},
+		"not minter": {
+			Caller:        "owner",
+			TokenURIs:     map[string]string{},
+			TokenID:       "tokenID",
+			URI:           "ipfs://token",
+			ExpectedURIs:  map[string]string{},
+			ExpectedError: ErrUnauthorized,
+		},
+		"token no exist": {
+			Caller:        "minter",
+			TokenURIs:     map[string]string{},
+			TokenID:       "tokenID2",
+			URI:           "ipfs://token",
+			ExpectedURIs:  map[string]string{},
+			ExpectedError: ErrNoExist,
+		},
+	}
+)
+
+func TestDefaultContract_TokenURI(t *testing.T) {
+	for name, test := range tokenURITests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner"}
+			contract.TokenURIs = test.TokenURIs
+			contract.BaseTokenURI = test.BaseURI
+			on := test.DCResponse != nil
+			var ret *dragonchain.Response
+			if on {
+				if test.DCResponse.Error == nil {
+					ret = &dragonchain.Response{
+						OK:       true,
+						Status:   http.StatusOK,
+						Response: []byte(test.DCResponse.Response),
+					}
+				}
+				mockClient.On("GetSmartContractObject", "baseURI", "").Once().Return(ret, test.DCResponse.Error)
+			}
+			uri, err := contract.TokenURI(test.TokenID)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedURI, uri)
+			if !on {
+				mockClient.AssertNotCalled(t, "GetSmartContractObject", "baseURI", "")
+			}
+		})
+	}
+}
+
+func TestDefaultContract_SetTokenURI(t *testing.T) {
+	for name, test := range setTokenURITests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner"}
+			contract.TokenURIs = test.TokenURIs
+			contract.ContractMinter = "minter"
+			contract.SetCaller(test.Caller)
+			err := contract.SetTokenURI(test.TokenID, test.URI)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedURIs, contract.TokenURIs)
+		})
+	}
+}
+
+func TestDefaultContract_SetBaseURI(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.ContractMinter = "minter"
+
+	contract.SetCaller("owner")
+	assert.Equal(t, ErrUnauthorized, contract.SetBaseURI("https://example.com/"))
+
+	contract.SetCaller("minter")
+	assert.NoError(t, contract.SetBaseURI("https://example.com/"))
+	uri, err := contract.BaseURI()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/", uri)
+
+	// Clearing the base uri must not cause it to be fetched from the heap again.
+	assert.NoError(t, contract.SetBaseURI(""))
+	uri, err = contract.BaseURI()
+	assert.NoError(t, err)
+	assert.Equal(t, "", uri)
+	mockClient.AssertNotCalled(t, "GetSmartContractObject", "baseURI", "")
+	// The cleared base uri must overwrite the one on the heap.
+	assert.JSONEq(t, `""`, string(heapOutput(t, contract)["baseURI"]))
+}
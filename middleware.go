@@ -0,0 +1,92 @@
+package nft
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ErrPanic is returned by the Recover middleware when a handler panics.
+var ErrPanic = errors.New("handler panicked")
+
+// Middleware wraps an RPCHandler to add behaviour around every RPC, such as logging or
+// authorization.
+type Middleware func(RPCHandler) RPCHandler
+
+// Chain wraps handler in middleware. The first middleware is the outermost, so it sees each
+// RPC first and its result last.
+func Chain(handler RPCHandler, middleware ...Middleware) RPCHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// Logging logs every RPC and its outcome to logger. The caller is included for contracts
+// that implement RoleChecker.
+func Logging(logger *log.Logger) Middleware {
+	return func(next RPCHandler) RPCHandler {
+		return RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+			caller := ""
+			if checker, ok := contract.(RoleChecker); ok {
+				caller = checker.Caller()
+			}
+			logger.Printf("handling RPC from %q (%d bytes)", caller, len(input))
+			obj, err := next.HandleRPC(input, contract)
+			if err != nil {
+				logger.Printf("RPC failed with %s: %s", CodeOf(err), err)
+				return obj, err
+			}
+			logger.Printf("RPC succeeded")
+			return obj, nil
+		})
+	}
+}
+
+// Recover turns panics in the handler into errors wrapping ErrPanic, so that they are reported
+// like any other internal failure.
+func Recover() Middleware {
+	return func(next RPCHandler) RPCHandler {
+		return RPCHandlerFunc(func(input []byte, contract Contract) (obj interface{}, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					obj, err = nil, fmt.Errorf("%w: %v", ErrPanic, r)
+				}
+			}()
+			return next.HandleRPC(input, contract)
+		})
+	}
+}
+
+// Authorize rejects every RPC whose caller does not hold role with ErrUnauthorized, as
+// RequireRole does.
+func Authorize(role string) Middleware {
+	return func(next RPCHandler) RPCHandler {
+		return RequireRole(role, next)
+	}
+}
+
+// MaxInputSize rejects RPCs whose input is larger than limit bytes with ErrInputTooLarge.
+func MaxInputSize(limit int) Middleware {
+	return func(next RPCHandler) RPCHandler {
+		return RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+			if len(input) > limit {
+				return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrInputTooLarge, len(input), limit)
+			}
+			return next.HandleRPC(input, contract)
+		})
+	}
+}
+
+// Timing calls observe with the time each RPC took to handle and its error, if any.
+func Timing(observe func(elapsed time.Duration, err error)) Middleware {
+	return func(next RPCHandler) RPCHandler {
+		return RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+			start := time.Now()
+			obj, err := next.HandleRPC(input, contract)
+			observe(time.Since(start), err)
+			return obj, err
+		})
+	}
+}
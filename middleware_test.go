@@ -0,0 +1,143 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echoHandler returns its input as a string.
+var echoHandler = RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+	return string(input), nil
+})
+
+func TestChain(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RPCHandler) RPCHandler {
+			return RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+				order = append(order, name+" before")
+				obj, err := next.HandleRPC(input, contract)
+				order = append(order, name+" after")
+				return obj, err
+			})
+		}
+	}
+	obj, err := Chain(echoHandler, trace("outer"), trace("inner")).HandleRPC([]byte("input"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "input", obj)
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, order)
+
+	obj, err = Chain(echoHandler).HandleRPC([]byte("input"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "input", obj)
+}
+
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	contract := NewDefaultContract("test", "TEST", &MockClient{})
+	contract.SetCaller("owner")
+	handler := Logging(log.New(&buf, "", 0))(echoHandler)
+	_, err := handler.HandleRPC([]byte("input"), contract)
+	assert.NoError(t, err)
+	assert.Equal(t, "handling RPC from \"owner\" (5 bytes)\nRPC succeeded\n", buf.String())
+
+	buf.Reset()
+	handler = Logging(log.New(&buf, "", 0))(RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+		return nil, ErrNoExist
+	}))
+	_, err = handler.HandleRPC([]byte("input"), contract)
+	assert.Equal(t, ErrNoExist, err)
+	assert.Equal(t, "handling RPC from \"owner\" (5 bytes)\nRPC failed with not_found: resource does not exist\n", buf.String())
+}
+
+func TestRecover(t *testing.T) {
+	handler := Recover()(RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+		panic("boom")
+	}))
+	obj, err := handler.HandleRPC([]byte("input"), nil)
+	assert.Nil(t, obj)
+	assert.True(t, errors.Is(err, ErrPanic))
+	assert.Equal(t, "handler panicked: boom", err.Error())
+	assert.Equal(t, CodeInternal, CodeOf(err))
+
+	obj, err = Recover()(echoHandler).HandleRPC([]byte("input"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "input", obj)
+}
+
+func TestAuthorize(t *testing.T) {
+	contract := NewDefaultContract("test", "TEST", &MockClient{})
+	contract.ContractMinter = "minter"
+	handler := Authorize(MinterRole)(echoHandler)
+
+	contract.SetCaller("minter")
+	obj, err := handler.HandleRPC([]byte("input"), contract)
+	assert.NoError(t, err)
+	assert.Equal(t, "input", obj)
+
+	contract.Roles = make(map[string]map[string]bool)
+	contract.SetCaller("owner")
+	_, err = handler.HandleRPC([]byte("input"), contract)
+	assert.Equal(t, ErrUnauthorized, err)
+}
+
+func TestMaxInputSize(t *testing.T) {
+	handler := MaxInputSize(5)(echoHandler)
+	obj, err := handler.HandleRPC([]byte("input"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "input", obj)
+
+	_, err = handler.HandleRPC([]byte("inputs"), nil)
+	assert.True(t, errors.Is(err, ErrInputTooLarge))
+	assert.Equal(t, ExitInvalidRequest, ExitCodeOf(CodeOf(err)))
+}
+
+func TestTiming(t *testing.T) {
+	var elapsed time.Duration
+	var observedErr error
+	handler := Timing(func(d time.Duration, err error) {
+		elapsed, observedErr = d, err
+	})(RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return nil, errFailed
+	}))
+	_, err := handler.HandleRPC([]byte("input"), nil)
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, errFailed, observedErr)
+	assert.True(t, elapsed >= 10*time.Millisecond)
+}
+
+func TestRuntime_Middleware(t *testing.T) {
+	var logs bytes.Buffer
+	panicky := NewDispatcher()
+	panicky.Register("panic", func(params json.RawMessage, contract Contract) (interface{}, error) {
+		panic("boom")
+	})
+	env := map[string]string{"CONTRACT_NAME": "test", "CONTRACT_SYMBOL": "TEST", "CONTRACT_MINTER": "minter"}
+	rt := NewRuntime(panicky, &testContractFactory{}, Recover(), Logging(log.New(&logs, "", 0)), MaxInputSize(200))
+
+	// Invocation handlers still receive the parsed invocation behind the middleware.
+	var stdout, stderr bytes.Buffer
+	input := `{"header": {"txn_id": "txnID", "invoker": "minter"}, "payload": {"method": "name"}}`
+	assert.NoError(t, rt.RunWith(context.Background(), bytes.NewBufferString(input), &stdout, &stderr, env))
+	assert.JSONEq(t, `{"result": "test", "events": []}`, stdout.String())
+	assert.Equal(t, "handling RPC from \"minter\" (83 bytes)\nRPC succeeded\n", logs.String())
+
+	stdout.Reset()
+	input = `{"header": {"txn_id": "txnID"}, "payload": {"method": "panic"}}`
+	err := rt.RunWith(context.Background(), bytes.NewBufferString(input), &stdout, &stderr, env)
+	assert.True(t, errors.Is(err, ErrPanic))
+	assert.JSONEq(t, `{"error": {"code": "internal", "message": "failed to handle RPC: handler panicked: boom"}}`, stdout.String())
+
+	stdout.Reset()
+	input = `{"header": {"txn_id": "txnID"}, "payload": {"method": "name", "params": {"padding": "` + string(bytes.Repeat([]byte("x"), 200)) + `"}}}`
+	err = rt.RunWith(context.Background(), bytes.NewBufferString(input), &stdout, &stderr, env)
+	assert.True(t, errors.Is(err, ErrInputTooLarge))
+}
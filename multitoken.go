@@ -0,0 +1,465 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+var (
+	// ErrNotSupported is returned when a Contract method has no meaning for a contract implementation.
+	ErrNotSupported = errors.New("operation not supported")
+	// ErrNotUnique is returned when a single owner is requested for a token that has several holders.
+	ErrNotUnique = errors.New("token has more than one holder")
+	// ErrInvalidAmount is returned when a token amount is not positive.
+	ErrInvalidAmount = errors.New("amount must be positive")
+	// ErrInsufficientBalance is returned when an address holds fewer tokens than an operation needs.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrLengthMismatch is returned when paired argument lists have different lengths.
+	ErrLengthMismatch = errors.New("argument lengths do not match")
+)
+
+// MultiTokenContract is an ERC-1155-style smart contract in which every token id has a fungible
+// supply that can be split between any number of holders. A token id with a supply of one behaves
+// like a non-fungible token.
+//
+// MultiTokenContract implements Contract so that it can be run by a Runtime. The Contract methods
+// operate on a single unit of a token; use the Amount methods to work with larger quantities.
+type MultiTokenContract struct {
+	// Balances maps a token id to the amount held by each holder, as base 10 strings.
+	Balances map[string]map[string]string `json:"balances,omitempty"`
+	// Supplies maps a token id to its total supply, as a base 10 string.
+	Supplies          map[string]string          `json:"supplies,omitempty"`
+	OperatorApprovals map[string]map[string]bool `json:"operatorApprovals,omitempty"`
+
+	ContractName   string `json:"name"`
+	ContractSymbol string `json:"symbol"`
+	// ContractMinter is the only address that is allowed to mint new tokens.
+	ContractMinter string `json:"minter,omitempty"`
+
+	client Client
+	caller string
+}
+
+// NewMultiTokenContract returns a MultiTokenContract that uses the provided DragonChain client.
+func NewMultiTokenContract(name, symbol string, client Client) *MultiTokenContract {
+	return &MultiTokenContract{
+		ContractName:   name,
+		ContractSymbol: symbol,
+		client:         client,
+	}
+}
+
+// SetCaller sets the address that invoked the contract. Every operation that changes
+// the state of the contract is authorized against this address. The Runtime sets the caller
+// to the invoker of the transaction it runs.
+func (c *MultiTokenContract) SetCaller(address string) {
+	c.caller = address
+}
+
+// Caller returns the address that invoked the contract.
+func (c *MultiTokenContract) Caller() string {
+	return c.caller
+}
+
+// SetInvocation sets the caller of the contract from the transaction that invoked it.
+func (c *MultiTokenContract) SetInvocation(inv Invocation) {
+	c.SetCaller(inv.Invoker)
+}
+
+// Name returns the name of the Contract.
+func (c *MultiTokenContract) Name() string {
+	return c.ContractName
+}
+
+// Symbol returns the Contract's symbol.
+func (c *MultiTokenContract) Symbol() string {
+	return c.ContractSymbol
+}
+
+// BalanceOf returns the number of distinct token ids of which owner holds at least one unit.
+func (c *MultiTokenContract) BalanceOf(owner string) (uint64, error) {
+	tokens, err := c.TokensOwnedBy(owner)
+	return uint64(len(tokens)), err
+}
+
+// OwnerOf returns the only holder of a token. ErrNoExist is returned if nobody holds the token
+// and ErrNotUnique if more than one address does.
+func (c *MultiTokenContract) OwnerOf(tokenID string) (string, error) {
+	if c.Balances == nil {
+		if err := c.fetchBalances(); err != nil {
+			return "", err
+		}
+	}
+	holders := c.Balances[tokenID]
+	switch len(holders) {
+	case 0:
+		return "", ErrNoExist
+	case 1:
+		for owner := range holders {
+			return owner, nil
+		}
+	}
+	return "", ErrNotUnique
+}
+
+// Mint mints a single unit of a token to the "to" address.
+func (c *MultiTokenContract) Mint(to, tokenID string) error {
+	return c.MintAmount(to, tokenID, bigOne)
+}
+
+// Burn destroys a single unit of a token held by its only holder.
+func (c *MultiTokenContract) Burn(tokenID string) error {
+	owner, err := c.OwnerOf(tokenID)
+	if err != nil {
+		return err
+	}
+	return c.BurnAmount(owner, tokenID, bigOne)
+}
+
+// Transfer transfers a single unit of a token from the "from" address to the "to" address.
+func (c *MultiTokenContract) Transfer(from, to, tokenID string) error {
+	return c.TransferAmount(from, to, tokenID, bigOne)
+}
+
+// TotalSupply returns the number of distinct token ids in existence.
+func (c *MultiTokenContract) TotalSupply() (*big.Int, error) {
+	if c.Supplies == nil {
+		if err := c.fetchSupplies(); err != nil {
+			return BigZero, err
+		}
+	}
+	return big.NewInt(int64(len(c.Supplies))), nil
+}
+
+// TokensOwnedBy returns the sorted ids of the tokens of which owner holds at least one unit.
+func (c *MultiTokenContract) TokensOwnedBy(owner string) ([]string, error) {
+	if c.Balances == nil {
+		if err := c.fetchBalances(); err != nil {
+			return nil, err
+		}
+	}
+	var tokens []string
+	for tokenID, holders := range c.Balances {
+		if _, ok := holders[owner]; ok {
+			tokens = append(tokens, tokenID)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNoExist
+	}
+	sort.Strings(tokens)
+	return tokens, nil
+}
+
+// Approve is not supported; use SetApprovalForAll instead.
+func (c *MultiTokenContract) Approve(owner, approved, tokenID string) error {
+	return ErrNotSupported
+}
+
+// GetApproved is not supported; use IsApprovedForAll instead.
+func (c *MultiTokenContract) GetApproved(tokenID string) (string, error) {
+	return "", ErrNotSupported
+}
+
+// SetApprovalForAll allows or disallows operator to manage all of owner's tokens.
+// Only the owner may change its own operators.
+func (c *MultiTokenContract) SetApprovalForAll(owner, operator string, approved bool) error {
+	if c.caller == "" || c.caller != owner {
+		return ErrUnauthorized
+	}
+	if c.OperatorApprovals == nil {
+		if err := c.fetchOperatorApprovals(); err != nil {
+			return err
+		}
+	}
+	if approved {
+		if c.OperatorApprovals[owner] == nil {
+			c.OperatorApprovals[owner] = make(map[string]bool)
+		}
+		c.OperatorApprovals[owner][operator] = true
+		return nil
+	}
+	delete(c.OperatorApprovals[owner], operator)
+	if len(c.OperatorApprovals[owner]) == 0 {
+		delete(c.OperatorApprovals, owner)
+	}
+	return nil
+}
+
+// IsApprovedForAll reports whether operator is allowed to manage all of owner's tokens.
+func (c *MultiTokenContract) IsApprovedForAll(owner, operator string) (bool, error) {
+	if c.OperatorApprovals == nil {
+		if err := c.fetchOperatorApprovals(); err != nil {
+			return false, err
+		}
+	}
+	return c.OperatorApprovals[owner][operator], nil
+}
+
+// BalanceOfToken returns the amount of a token held by owner.
+func (c *MultiTokenContract) BalanceOfToken(owner, tokenID string) (*big.Int, error) {
+	if c.Balances == nil {
+		if err := c.fetchBalances(); err != nil {
+			return BigZero, err
+		}
+	}
+	return c.balance(owner, tokenID)
+}
+
+// BalanceOfBatch returns the amount of tokenIDs[i] held by owners[i] for every i.
+func (c *MultiTokenContract) BalanceOfBatch(owners, tokenIDs []string) ([]*big.Int, error) {
+	if len(owners) != len(tokenIDs) {
+		return nil, ErrLengthMismatch
+	}
+	balances := make([]*big.Int, len(owners))
+	for i := range owners {
+		balance, err := c.BalanceOfToken(owners[i], tokenIDs[i])
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = balance
+	}
+	return balances, nil
+}
+
+// SupplyOf returns the total amount of a token in existence.
+func (c *MultiTokenContract) SupplyOf(tokenID string) (*big.Int, error) {
+	if c.Supplies == nil {
+		if err := c.fetchSupplies(); err != nil {
+			return BigZero, err
+		}
+	}
+	if supply, ok := c.Supplies[tokenID]; ok {
+		return BigIntString(supply)
+	}
+	return new(big.Int), nil
+}
+
+// MintAmount mints amount units of a token to the "to" address. Only the contract's minter
+// may mint tokens.
+func (c *MultiTokenContract) MintAmount(to, tokenID string, amount *big.Int) error {
+	if c.caller == "" || c.caller != c.ContractMinter {
+		return ErrUnauthorized
+	}
+	if amount.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+	if err := c.loadBalances(); err != nil {
+		return err
+	}
+	balance, err := c.balance(to, tokenID)
+	if err != nil {
+		return err
+	}
+	supply, err := c.SupplyOf(tokenID)
+	if err != nil {
+		return err
+	}
+	c.setBalance(to, tokenID, balance.Add(balance, amount))
+	c.Supplies[tokenID] = supply.Add(supply, amount).String()
+	return nil
+}
+
+// TransferAmount transfers amount units of a token from the "from" address to the "to" address.
+// The caller must be the "from" address or one of its approved operators.
+func (c *MultiTokenContract) TransferAmount(from, to, tokenID string, amount *big.Int) error {
+	if err := c.authorize(from); err != nil {
+		return err
+	}
+	if amount.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+	if err := c.loadBalances(); err != nil {
+		return err
+	}
+	fromBalance, err := c.balance(from, tokenID)
+	if err != nil {
+		return err
+	}
+	if fromBalance.Cmp(amount) < 0 {
+		return ErrInsufficientBalance
+	}
+	c.setBalance(from, tokenID, fromBalance.Sub(fromBalance, amount))
+	toBalance, err := c.balance(to, tokenID)
+	if err != nil {
+		return err
+	}
+	c.setBalance(to, tokenID, toBalance.Add(toBalance, amount))
+	return nil
+}
+
+// BurnAmount destroys amount units of a token held by the "from" address. The caller must be
+// the "from" address or one of its approved operators.
+func (c *MultiTokenContract) BurnAmount(from, tokenID string, amount *big.Int) error {
+	if err := c.authorize(from); err != nil {
+		return err
+	}
+	if amount.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+	if err := c.loadBalances(); err != nil {
+		return err
+	}
+	balance, err := c.balance(from, tokenID)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(amount) < 0 {
+		return ErrInsufficientBalance
+	}
+	supply, err := c.SupplyOf(tokenID)
+	if err != nil {
+		return err
+	}
+	c.setBalance(from, tokenID, balance.Sub(balance, amount))
+	supply.Sub(supply, amount)
+	if supply.Sign() == 0 {
+		delete(c.Supplies, tokenID)
+	} else {
+		c.Supplies[tokenID] = supply.String()
+	}
+	return nil
+}
+
+// MarshalJSON encodes the heap output of the contract like DefaultContract.MarshalJSON.
+func (c *MultiTokenContract) MarshalJSON() ([]byte, error) {
+	type heapContract MultiTokenContract
+	return marshalHeap((*heapContract)(c), nil)
+}
+
+// GetDragonObject fetches an object with the provided key from the DragonChain smart
+// contract's heap. An error is returned if the object could not be fetched.
+func (c *MultiTokenContract) GetDragonObject(key string) ([]byte, error) {
+	return getDragonObject(c.client, key)
+}
+
+// authorize returns ErrUnauthorized unless the caller is owner or one of owner's operators.
+func (c *MultiTokenContract) authorize(owner string) error {
+	if c.caller == "" {
+		return ErrUnauthorized
+	}
+	if c.caller == owner {
+		return nil
+	}
+	ok, err := c.IsApprovedForAll(owner, c.caller)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func (c *MultiTokenContract) loadBalances() error {
+	if c.Balances == nil {
+		if err := c.fetchBalances(); err != nil {
+			return err
+		}
+	}
+	if c.Supplies == nil {
+		if err := c.fetchSupplies(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// balance returns the amount of tokenID held by owner. The balances must be loaded.
+func (c *MultiTokenContract) balance(owner, tokenID string) (*big.Int, error) {
+	if balance, ok := c.Balances[tokenID][owner]; ok {
+		return BigIntString(balance)
+	}
+	return new(big.Int), nil
+}
+
+// setBalance records the amount of tokenID held by owner, forgetting holders with nothing left.
+// The balances must be loaded.
+func (c *MultiTokenContract) setBalance(owner, tokenID string, amount *big.Int) {
+	if amount.Sign() == 0 {
+		delete(c.Balances[tokenID], owner)
+		if len(c.Balances[tokenID]) == 0 {
+			delete(c.Balances, tokenID)
+		}
+		return
+	}
+	if c.Balances[tokenID] == nil {
+		c.Balances[tokenID] = make(map[string]string)
+	}
+	c.Balances[tokenID][owner] = amount.String()
+}
+
+func (c *MultiTokenContract) fetchBalances() error {
+	resp, err := c.GetDragonObject("balances")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.Balances = make(map[string]map[string]string)
+		return nil
+	}
+	var m map[string]map[string]string
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.Balances = m
+	return nil
+}
+
+func (c *MultiTokenContract) fetchSupplies() error {
+	resp, err := c.GetDragonObject("supplies")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.Supplies = make(map[string]string)
+		return nil
+	}
+	var m map[string]string
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.Supplies = m
+	return nil
+}
+
+func (c *MultiTokenContract) fetchOperatorApprovals() error {
+	resp, err := c.GetDragonObject("operatorApprovals")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.OperatorApprovals = make(map[string]map[string]bool)
+		return nil
+	}
+	var m map[string]map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.OperatorApprovals = m
+	return nil
+}
+
+// MultiTokenContractFactory creates a new MultiTokenContract from the heap.
+type MultiTokenContractFactory struct{}
+
+// CreateContract returns a new MultiTokenContract configured from the process environment, as
+// described for CreateContractFromEnv.
+func (f *MultiTokenContractFactory) CreateContract(name, symbol string) (Contract, error) {
+	return f.CreateContractFromEnv(name, symbol, environ())
+}
+
+// CreateContractFromEnv returns a new MultiTokenContract. The contract's minter is read from
+// the CONTRACT_MINTER variable of env.
+func (f *MultiTokenContractFactory) CreateContractFromEnv(name, symbol string, env map[string]string) (Contract, error) {
+	dcClient, err := dragonClient(env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dragonchain client: %s", err)
+	}
+	contract := NewMultiTokenContract(name, symbol, dcClient)
+	contract.ContractMinter = env["CONTRACT_MINTER"]
+	return contract, nil
+}
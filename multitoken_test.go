@@ -0,0 +1,337 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Contract = (*MultiTokenContract)(nil)
+
+	mintAmountTests = map[string]struct {
+		Caller           string
+		To               string
+		TokenID          string
+		Amount           *big.Int
+		ExpectedBalances map[string]map[string]string
+		ExpectedSupplies map[string]string
+		ExpectedError    error
+	}{
+		"new token minted": {
+			Caller:           "minter",
+			To:               "owner2",
+			TokenID:          "potion",
+			Amount:           big.NewInt(10),
+			ExpectedBalances: map[string]map[string]string{"sword": {"owner": "1"}, "gold": {"owner": "100"}, "potion": {"owner2": "10"}},
+			ExpectedSupplies: map[string]string{"sword": "1", "gold": "100", "potion": "10"},
+		},
+		"existing token minted": {
+			Caller:           "minter",
+			To:               "owner2",
+			TokenID:          "gold",
+			Amount:           big.NewInt(50),
+			ExpectedBalances: map[string]map[string]string{"sword": {"owner": "1"}, "gold": {"owner": "100", "owner2": "50"}},
+			ExpectedSupplies: map[string]string{"sword": "1", "gold": "150"},
+		},
+		"not minter": {
+			Caller:        "owner",
+			To:            "owner",
+			TokenID:       "gold",
+			Amount:        bigOne,
+			ExpectedError: ErrUnauthorized,
+		},
+		"zero amount": {
+			Caller:        "minter",
+			To:            "owner",
+			TokenID:       "gold",
+			Amount:        BigZero,
+			ExpectedError: ErrInvalidAmount,
+		},
+	}
+
+	transferAmountTests = map[string]struct {
+		Caller           string
+		From             string
+		To               string
+		TokenID          string
+		Amount           *big.Int
+		ExpectedBalances map[string]map[string]string
+		ExpectedError    error
+	}{
+		"partial transfer": {
+			Caller:           "owner",
+			From:             "owner",
+			To:               "owner2",
+			TokenID:          "gold",
+			Amount:           big.NewInt(40),
+			ExpectedBalances: map[string]map[string]string{"sword": {"owner": "1"}, "gold": {"owner": "60", "owner2": "40"}},
+		},
+		"whole balance transferred by operator": {
+			Caller:           "operator",
+			From:             "owner",
+			To:               "owner2",
+			TokenID:          "sword",
+			Amount:           bigOne,
+			ExpectedBalances: map[string]map[string]string{"sword": {"owner2": "1"}, "gold": {"owner": "100"}},
+		},
+		"insufficient balance": {
+			Caller:        "owner",
+			From:          "owner",
+			To:            "owner2",
+			TokenID:       "gold",
+			Amount:        big.NewInt(101),
+			ExpectedError: ErrInsufficientBalance,
+		},
+		"unauthorized caller": {
+			Caller:        "owner2",
+			From:          "owner",
+			To:            "owner2",
+			TokenID:       "gold",
+			Amount:        bigOne,
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
+	burnAmountTests = map[string]struct {
+		Caller           string
+		From             string
+		TokenID          string
+		Amount           *big.Int
+		ExpectedBalances map[string]map[string]string
+		ExpectedSupplies map[string]string
+		ExpectedError    error
+	}{
+		"partial burn": {
+			Caller:           "owner",
+			From:             "owner",
+			TokenID:          "gold",
+			Amount:           big.NewInt(30),
+			ExpectedBalances: map[string]map[string]string{"sword": {"owner": "1"}, "gold": {"owner": "70"}},
+			ExpectedSupplies: map[string]string{"sword": "1", "gold": "70"},
+		},
+		"whole supply burned": {
+			Caller:           "owner",
+			From:             "owner",
+			TokenID:          "sword",
+			Amount:           bigOne,
+			ExpectedBalances: map[string]map[string]string{"gold": {"owner": "100"}},
+			ExpectedSupplies: map[string]string{"gold": "100"},
+		},
+		"insufficient balance": {
+			Caller:        "owner",
+			From:          "owner",
+			TokenID:       "sword",
+			Amount:        big.NewInt(2),
+			ExpectedError: ErrInsufficientBalance,
+		},
+		"unauthorized caller": {
+			Caller:        "owner2",
+			From:          "owner",
+			TokenID:       "sword",
+			Amount:        bigOne,
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
+	multiOwnerOfTests = map[string]struct {
+		TokenID       string
+		ExpectedOwner string
+		ExpectedError error
+	}{
+		"single holder": {
+			TokenID:       "sword",
+			ExpectedOwner: "owner",
+		},
+		"several holders": {
+			TokenID:       "gold",
+			ExpectedError: ErrNotUnique,
+		},
+		"no holders": {
+			TokenID:       "potion",
+			ExpectedError: ErrNoExist,
+		},
+	}
+)
+
+// newMultiTokenTestContract returns a contract in which owner holds one sword and 100 gold,
+// and operator is an approved operator of owner.
+func newMultiTokenTestContract() *MultiTokenContract {
+	contract := NewMultiTokenContract("test", "TEST", &MockClient{})
+	contract.Balances = map[string]map[string]string{"sword": {"owner": "1"}, "gold": {"owner": "100"}}
+	contract.Supplies = map[string]string{"sword": "1", "gold": "100"}
+	contract.OperatorApprovals = map[string]map[string]bool{"owner": {"operator": true}}
+	contract.ContractMinter = "minter"
+	return contract
+}
+
+func TestMultiTokenContract_MintAmount(t *testing.T) {
+	for name, test := range mintAmountTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newMultiTokenTestContract()
+			contract.SetCaller(test.Caller)
+			err := contract.MintAmount(test.To, test.TokenID, test.Amount)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, newMultiTokenTestContract().Balances, contract.Balances)
+				return
+			}
+			assert.Equal(t, test.ExpectedBalances, contract.Balances)
+			assert.Equal(t, test.ExpectedSupplies, contract.Supplies)
+		})
+	}
+}
+
+func TestMultiTokenContract_TransferAmount(t *testing.T) {
+	for name, test := range transferAmountTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newMultiTokenTestContract()
+			contract.SetCaller(test.Caller)
+			err := contract.TransferAmount(test.From, test.To, test.TokenID, test.Amount)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, newMultiTokenTestContract().Balances, contract.Balances)
+				return
+			}
+			assert.Equal(t, test.ExpectedBalances, contract.Balances)
+			assert.Equal(t, newMultiTokenTestContract().Supplies, contract.Supplies)
+		})
+	}
+}
+
+func TestMultiTokenContract_BurnAmount(t *testing.T) {
+	for name, test := range burnAmountTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newMultiTokenTestContract()
+			contract.SetCaller(test.Caller)
+			err := contract.BurnAmount(test.From, test.TokenID, test.Amount)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Equal(t, newMultiTokenTestContract().Balances, contract.Balances)
+				return
+			}
+			assert.Equal(t, test.ExpectedBalances, contract.Balances)
+			assert.Equal(t, test.ExpectedSupplies, contract.Supplies)
+		})
+	}
+}
+
+func TestMultiTokenContract_OwnerOf(t *testing.T) {
+	for name, test := range multiOwnerOfTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newMultiTokenTestContract()
+			contract.Balances["gold"]["owner2"] = "5"
+			owner, err := contract.OwnerOf(test.TokenID)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedOwner, owner)
+		})
+	}
+}
+
+func TestMultiTokenContract_BalanceOfBatch(t *testing.T) {
+	contract := newMultiTokenTestContract()
+	balances, err := contract.BalanceOfBatch([]string{"owner", "owner", "owner2"}, []string{"gold", "sword", "gold"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(100), big.NewInt(1), new(big.Int)}, balances)
+
+	_, err = contract.BalanceOfBatch([]string{"owner"}, []string{"gold", "sword"})
+	assert.Equal(t, ErrLengthMismatch, err)
+}
+
+func TestMultiTokenContract_ContractMethods(t *testing.T) {
+	contract := newMultiTokenTestContract()
+	var c Contract = contract
+
+	tokens, err := c.TokensOwnedBy("owner")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gold", "sword"}, tokens)
+	balance, err := c.BalanceOf("owner")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), balance)
+	supply, err := c.TotalSupply()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", supply.String())
+
+	contract.SetCaller("owner")
+	assert.NoError(t, c.Transfer("owner", "owner2", "sword"))
+	owner, err := c.OwnerOf("sword")
+	assert.NoError(t, err)
+	assert.Equal(t, "owner2", owner)
+
+	contract.SetCaller("owner2")
+	assert.NoError(t, c.Burn("sword"))
+	_, err = c.OwnerOf("sword")
+	assert.Equal(t, ErrNoExist, err)
+
+	assert.Equal(t, ErrNotSupported, c.Approve("owner", "approved", "gold"))
+}
+
+func TestMultiTokenContract_FetchBalances(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetSmartContractObject", "balances", "").Once().Return(&dragonchain.Response{
+		OK:       true,
+		Status:   http.StatusOK,
+		Response: []byte(`{"gold": {"owner": "100"}}`),
+	}, nil)
+	contract := NewMultiTokenContract("test", "TEST", mockClient)
+	balance, err := contract.BalanceOfToken("owner", "gold")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", balance.String())
+	balance, err = contract.BalanceOfToken("owner2", "gold")
+	assert.NoError(t, err)
+	assert.Equal(t, "0", balance.String())
+	mockClient.AssertNumberOfCalls(t, "GetSmartContractObject", 1)
+}
+
+func TestMultiTokenContract_MarshalJSON(t *testing.T) {
+	contract := newMultiTokenTestContract()
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.BurnAmount("owner", "sword", bigOne))
+	assert.NoError(t, contract.BurnAmount("owner", "gold", big.NewInt(100)))
+	assert.NoError(t, contract.SetApprovalForAll("owner", "operator", false))
+
+	b, err := json.Marshal(contract)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "test",
+		"symbol": "TEST",
+		"minter": "minter",
+		"balances": {},
+		"supplies": {},
+		"operatorApprovals": {}
+	}`, string(b))
+}
+
+func TestMultiTokenContract_RunWith(t *testing.T) {
+	// The heap is empty, so every object is fetched as not found.
+	heap := httptest.NewServer(http.NotFoundHandler())
+	defer heap.Close()
+	rt := NewRuntime(NewDispatcher(), &MultiTokenContractFactory{})
+	input := `{"header": {"txn_id": "txnID", "invoker": "minter"}, "payload": {"method": "mint", "params": {"to": "owner", "tokenId": "sword"}}}`
+	var stdout, stderr bytes.Buffer
+	env := map[string]string{
+		"CONTRACT_NAME":        "test",
+		"CONTRACT_SYMBOL":      "TEST",
+		"CONTRACT_MINTER":      "minter",
+		"DRAGONCHAIN_ENDPOINT": heap.URL,
+		"DRAGONCHAIN_ID":       "dcID",
+		"AUTH_KEY":             "key",
+		"AUTH_KEY_ID":          "keyID",
+	}
+	err := rt.RunWith(context.Background(), bytes.NewBufferString(input), &stdout, &stderr, env)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "test",
+		"symbol": "TEST",
+		"minter": "minter",
+		"balances": {"sword": {"owner": "1"}},
+		"supplies": {"sword": "1"}
+	}`, stdout.String())
+}
@@ -0,0 +1,65 @@
+package nft
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrPaused is returned by every operation that changes the state of a paused contract.
+var ErrPaused = errors.New("contract is paused")
+
+// Paused reports whether the contract is paused.
+func (c *DefaultContract) Paused() (bool, error) {
+	if c.ContractPaused == nil {
+		if err := c.fetchPaused(); err != nil {
+			return false, err
+		}
+	}
+	return *c.ContractPaused, nil
+}
+
+// Pause freezes the contract. While it is paused, every operation that changes its state
+// fails with ErrPaused; reading it keeps working. Only holders of PauserRole may pause it.
+func (c *DefaultContract) Pause() error {
+	return c.setPaused(true)
+}
+
+// Unpause lifts a previous Pause. Only holders of PauserRole may unpause it.
+func (c *DefaultContract) Unpause() error {
+	return c.setPaused(false)
+}
+
+func (c *DefaultContract) setPaused(paused bool) error {
+	if err := c.requireRole(PauserRole); err != nil {
+		return err
+	}
+	c.ContractPaused = &paused
+	return nil
+}
+
+// whenNotPaused returns ErrPaused if the contract is paused.
+func (c *DefaultContract) whenNotPaused() error {
+	paused, err := c.Paused()
+	if err != nil {
+		return err
+	}
+	if paused {
+		return ErrPaused
+	}
+	return nil
+}
+
+func (c *DefaultContract) fetchPaused() error {
+	resp, err := c.GetDragonObject("paused")
+	if err != nil {
+		return err
+	}
+	paused := false
+	if len(resp) != 0 {
+		if paused, err = strconv.ParseBool(string(resp)); err != nil {
+			return err
+		}
+	}
+	c.ContractPaused = &paused
+	return nil
+}
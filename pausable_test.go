@@ -0,0 +1,177 @@
+package nft
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pausedTests = map[string]struct {
+		DCResponse     *dcResp
+		ExpectedPaused bool
+		ExpectedError  error
+	}{
+		"fetch paused": {
+			DCResponse: &dcResp{
+				Response: "true",
+			},
+			ExpectedPaused: true,
+		},
+		"fetch unpaused": {
+			DCResponse: &dcResp{
+				Response: "false",
+			},
+		},
+		"not in heap": {
+			DCResponse: &dcResp{},
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			ExpectedError: errFailed,
+		},
+	}
+
+	pauseTests = map[string]struct {
+		Caller         string
+		Operation      func(c *DefaultContract) error
+		ExpectedPaused bool
+		ExpectedError  error
+	}{
+		"paused by admin": {
+			Caller:         "admin",
+			Operation:      (*DefaultContract).Pause,
+			ExpectedPaused: true,
+		},
+		"unpaused by admin": {
+			Caller:    "admin",
+			Operation: (*DefaultContract).Unpause,
+		},
+		"paused by minter": {
+			Caller:        "minter",
+			Operation:     (*DefaultContract).Pause,
+			ExpectedError: ErrUnauthorized,
+		},
+		"paused without caller": {
+			Operation:     (*DefaultContract).Pause,
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
+	whilePausedTests = map[string]struct {
+		Caller    string
+		Operation func(c *DefaultContract) error
+	}{
+		"mint": {
+			Caller:    "minter",
+			Operation: func(c *DefaultContract) error { return c.Mint("owner", "tokenID4") },
+		},
+		"mint batch": {
+			Caller:    "minter",
+			Operation: func(c *DefaultContract) error { return c.MintBatch([]TokenMint{{To: "owner", TokenID: "tokenID4"}}) },
+		},
+		"transfer": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+		},
+		"safe transfer": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.SafeTransfer("owner", "owner2", "tokenID", nil) },
+		},
+		"burn": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Burn("tokenID") },
+		},
+		"approve": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Approve("owner", "approved", "tokenID") },
+		},
+		"set approval for all": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.SetApprovalForAll("owner", "operator", true) },
+		},
+		"set token uri": {
+			Caller:    "minter",
+			Operation: func(c *DefaultContract) error { return c.SetTokenURI("tokenID", "uri") },
+		},
+		"set attribute": {
+			Caller:    "minter",
+			Operation: func(c *DefaultContract) error { return c.SetAttribute("tokenID", "level", IntAttribute(1)) },
+		},
+		"set royalty": {
+			Caller:    "minter",
+			Operation: func(c *DefaultContract) error { return c.SetDefaultRoyalty("creator", 100) },
+		},
+	}
+)
+
+func TestDefaultContract_Paused(t *testing.T) {
+	for name, test := range pausedTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			var ret *dragonchain.Response
+			if test.DCResponse.Error == nil {
+				ret = &dragonchain.Response{
+					OK:       true,
+					Status:   http.StatusOK,
+					Response: []byte(test.DCResponse.Response),
+				}
+			}
+			mockClient.On("GetSmartContractObject", "paused", "").Once().Return(ret, test.DCResponse.Error)
+			paused, err := contract.Paused()
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedPaused, paused)
+		})
+	}
+}
+
+func TestDefaultContract_Pause(t *testing.T) {
+	for name, test := range pauseTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.ContractAdmin = "admin"
+			contract.ContractMinter = "minter"
+			contract.SetCaller(test.Caller)
+			err := test.Operation(contract)
+			assert.Equal(t, test.ExpectedError, err)
+			paused, err := contract.Paused()
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedPaused, paused)
+		})
+	}
+}
+
+func TestDefaultContract_WhilePaused(t *testing.T) {
+	for name, test := range whilePausedTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.ContractAdmin = "admin"
+			contract.SetCaller("admin")
+			assert.NoError(t, contract.Pause())
+
+			contract.SetCaller(test.Caller)
+			assert.Equal(t, ErrPaused, test.Operation(contract))
+
+			// Reads keep working while paused.
+			owner, err := contract.OwnerOf("tokenID")
+			assert.NoError(t, err)
+			assert.Equal(t, "owner", owner)
+			supply, err := contract.TotalSupply()
+			assert.NoError(t, err)
+			assert.Equal(t, big.NewInt(3), supply)
+
+			contract.SetCaller("admin")
+			assert.NoError(t, contract.Unpause())
+			contract.SetCaller(test.Caller)
+			assert.NoError(t, test.Operation(contract))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package nft
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTransferRejected is returned by SafeTransfer when the receiving address refuses the token.
+var ErrTransferRejected = errors.New("receiver rejected token transfer")
+
+// TokenReceiver decides whether an address accepts tokens sent to it with SafeTransfer.
+// It is modeled on ERC-721's onERC721Received.
+type TokenReceiver interface {
+	// OnTokenReceived is called before tokenID is moved from the "from" address to the
+	// receiver. operator is the caller that requested the transfer and data is passed
+	// through unchanged from SafeTransfer. Returning an error rejects the transfer.
+	OnTokenReceived(operator, from, tokenID string, data []byte) error
+}
+
+// TokenReceiverFunc is a convenience type that allows for using a function in place
+// of a TokenReceiver.
+type TokenReceiverFunc func(operator, from, tokenID string, data []byte) error
+
+// OnTokenReceived exists to satisfy the TokenReceiver interface. It is a straight pass-through
+// to the underlying function.
+func (f TokenReceiverFunc) OnTokenReceived(operator, from, tokenID string, data []byte) error {
+	return f(operator, from, tokenID, data)
+}
+
+// RegisterReceiver registers the TokenReceiver that is consulted whenever a token is sent to
+// address with SafeTransfer. Registering a nil receiver removes the registration.
+func (c *DefaultContract) RegisterReceiver(address string, receiver TokenReceiver) {
+	if receiver == nil {
+		delete(c.receivers, address)
+		return
+	}
+	if c.receivers == nil {
+		c.receivers = make(map[string]TokenReceiver)
+	}
+	c.receivers[address] = receiver
+}
+
+// SafeTransfer transfers a token like Transfer, but first asks the TokenReceiver registered
+// for the "to" address whether it accepts the token. The receiver is consulted after the
+// transfer has been validated and before anything is changed, so a rejected transfer leaves
+// the contract untouched and returns an error wrapping ErrTransferRejected. Addresses without
+// a registered receiver accept every token.
+func (c *DefaultContract) SafeTransfer(from, to, tokenID string, data []byte) error {
+	if err := c.prepareTransfer(from, tokenID); err != nil {
+		return err
+	}
+	if receiver, ok := c.receivers[to]; ok {
+		if err := receiver.OnTokenReceived(c.caller, from, tokenID, data); err != nil {
+			return fmt.Errorf("%w: %s", ErrTransferRejected, err)
+		}
+	}
+	c.transferToken(from, to, tokenID)
+	return nil
+}
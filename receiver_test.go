@@ -0,0 +1,89 @@
+package nft
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var safeTransferTests = map[string]struct {
+	Receiver      TokenReceiver
+	Caller        string
+	To            string
+	ExpectedOwner string
+	ExpectedError error
+}{
+	"receiver accepts": {
+		Receiver:      TokenReceiverFunc(func(operator, from, tokenID string, data []byte) error { return nil }),
+		Caller:        "owner",
+		To:            "receiver",
+		ExpectedOwner: "receiver",
+	},
+	"receiver rejects": {
+		Receiver:      TokenReceiverFunc(func(operator, from, tokenID string, data []byte) error { return errFailed }),
+		Caller:        "owner",
+		To:            "receiver",
+		ExpectedOwner: "owner",
+		ExpectedError: ErrTransferRejected,
+	},
+	"no receiver registered": {
+		Caller:        "owner",
+		To:            "owner2",
+		ExpectedOwner: "owner2",
+	},
+	"unauthorized caller": {
+		Receiver: TokenReceiverFunc(func(operator, from, tokenID string, data []byte) error {
+			panic("receiver must not be consulted for an invalid transfer")
+		}),
+		Caller:        "stranger",
+		To:            "receiver",
+		ExpectedOwner: "owner",
+		ExpectedError: ErrUnauthorized,
+	},
+}
+
+func TestDefaultContract_SafeTransfer(t *testing.T) {
+	for name, test := range safeTransferTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner"}
+			contract.OwnedTokens = map[string][]string{"owner": {"tokenID"}}
+			contract.OwnedTokenIndex = map[string]uint64{"tokenID": 0}
+			contract.TokenApprovals = map[string]string{"tokenID": "approved"}
+			contract.RegisterReceiver("receiver", test.Receiver)
+			contract.SetCaller(test.Caller)
+			err := contract.SafeTransfer("owner", test.To, "tokenID", []byte("data"))
+			assert.True(t, errors.Is(err, test.ExpectedError), "unexpected error %v", err)
+			assert.Equal(t, test.ExpectedOwner, contract.TokenOwners["tokenID"])
+			if test.ExpectedError != nil {
+				assert.Equal(t, map[string][]string{"owner": {"tokenID"}}, contract.OwnedTokens)
+				assert.Equal(t, "approved", contract.TokenApprovals["tokenID"])
+			}
+		})
+	}
+}
+
+func TestDefaultContract_SafeTransferReceiverArguments(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TokenOwners = map[string]string{"tokenID": "owner"}
+	contract.OwnedTokens = map[string][]string{"owner": {"tokenID"}}
+	contract.OwnedTokenIndex = map[string]uint64{"tokenID": 0}
+	contract.TokenApprovals = map[string]string{}
+	contract.OperatorApprovals = map[string]map[string]bool{"owner": {"operator": true}}
+	var got []string
+	contract.RegisterReceiver("receiver", TokenReceiverFunc(func(operator, from, tokenID string, data []byte) error {
+		got = []string{operator, from, tokenID, string(data)}
+		return nil
+	}))
+	contract.SetCaller("operator")
+	assert.NoError(t, contract.SafeTransfer("owner", "receiver", "tokenID", []byte("data")))
+	assert.Equal(t, []string{"operator", "owner", "tokenID", "data"}, got)
+
+	contract.RegisterReceiver("receiver", nil)
+	assert.Empty(t, contract.receivers)
+}
@@ -0,0 +1,104 @@
+package nft
+
+import "encoding/json"
+
+// Rental grants User the use of a token, but not its ownership, until Expires, a unix
+// timestamp in seconds.
+type Rental struct {
+	User    string `json:"user"`
+	Expires uint64 `json:"expires"`
+}
+
+// SetTimestamp sets the time of the invocation as a unix timestamp in seconds. Rentals are
+// expired against it, so until it is set no rental is considered active.
+func (c *DefaultContract) SetTimestamp(timestamp uint64) {
+	c.timestamp = timestamp
+}
+
+// SetUser lets user use a token until expires, a unix timestamp in seconds, following
+// ERC-4907. Setting user to "" ends the rental. The caller must own the token, be approved
+// for it, or be an approved operator of its owner. The rental ends when the token is
+// transferred or burned.
+func (c *DefaultContract) SetUser(tokenID, user string, expires uint64) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	owner, err := c.OwnerOf(tokenID)
+	if err != nil {
+		return err
+	}
+	if err := c.authorizeToken(owner, tokenID); err != nil {
+		return err
+	}
+	if err := c.loadRentals(); err != nil {
+		return err
+	}
+	if user == "" {
+		expires = 0
+		delete(c.TokenUsers, tokenID)
+	} else {
+		c.TokenUsers[tokenID] = Rental{User: user, Expires: expires}
+	}
+	c.emit(Event{Type: EventUser, To: user, TokenID: tokenID, Expires: expires})
+	return nil
+}
+
+// UserOf returns the current user of a token, or "" if the token is not rented, the rental
+// expired before the invocation timestamp, or the invocation timestamp is not known.
+func (c *DefaultContract) UserOf(tokenID string) (string, error) {
+	rental, err := c.rentalOf(tokenID)
+	if err != nil {
+		return "", err
+	}
+	if c.timestamp == 0 || rental.Expires < c.timestamp {
+		return "", nil
+	}
+	return rental.User, nil
+}
+
+// UserExpires returns the time at which the rental of a token expires, or zero if the token
+// is not rented.
+func (c *DefaultContract) UserExpires(tokenID string) (uint64, error) {
+	rental, err := c.rentalOf(tokenID)
+	if err != nil {
+		return 0, err
+	}
+	return rental.Expires, nil
+}
+
+func (c *DefaultContract) rentalOf(tokenID string) (Rental, error) {
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return Rental{}, err
+	}
+	if err := c.loadRentals(); err != nil {
+		return Rental{}, err
+	}
+	return c.TokenUsers[tokenID], nil
+}
+
+// loadRentals fetches the rentals from the heap if they have not been loaded yet.
+func (c *DefaultContract) loadRentals() error {
+	if c.TokenUsers == nil {
+		if err := c.fetchTokenUsers(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DefaultContract) fetchTokenUsers() error {
+	resp, err := c.GetDragonObject("tokenUsers")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenUsers = make(map[string]Rental)
+		return nil
+	}
+	var m map[string]Rental
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenUsers = m
+	return nil
+}
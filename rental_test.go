@@ -0,0 +1,176 @@
+package nft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	setUserTests = map[string]struct {
+		Caller          string
+		TokenID         string
+		User            string
+		Expires         uint64
+		ExpectedUser    string
+		ExpectedExpires uint64
+		ExpectedError   error
+	}{
+		"set by owner": {
+			Caller:          "owner",
+			TokenID:         "tokenID",
+			User:            "renter",
+			Expires:         2000,
+			ExpectedUser:    "renter",
+			ExpectedExpires: 2000,
+		},
+		"set by approved": {
+			Caller:          "approved",
+			TokenID:         "tokenID2",
+			User:            "renter",
+			Expires:         2000,
+			ExpectedUser:    "renter",
+			ExpectedExpires: 2000,
+		},
+		"expires at invocation": {
+			Caller:          "owner",
+			TokenID:         "tokenID",
+			User:            "renter",
+			Expires:         1000,
+			ExpectedUser:    "renter",
+			ExpectedExpires: 1000,
+		},
+		"expired": {
+			Caller:          "owner",
+			TokenID:         "tokenID",
+			User:            "renter",
+			Expires:         999,
+			ExpectedExpires: 999,
+		},
+		"cleared": {
+			Caller:  "owner",
+			TokenID: "tokenID",
+			Expires: 2000,
+		},
+		"set by stranger": {
+			Caller:        "owner2",
+			TokenID:       "tokenID",
+			User:          "renter",
+			Expires:       2000,
+			ExpectedError: ErrUnauthorized,
+		},
+		"token does not exist": {
+			Caller:        "owner",
+			TokenID:       "tokenID4",
+			User:          "renter",
+			Expires:       2000,
+			ExpectedError: ErrNoExist,
+		},
+	}
+
+	rentalEndTests = map[string]struct {
+		Caller    string
+		Operation func(c *DefaultContract) error
+	}{
+		"transfer": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+		},
+		"safe transfer": {
+			Caller:    "owner",
+			Operation: func(c *DefaultContract) error { return c.SafeTransfer("owner", "owner2", "tokenID", nil) },
+		},
+		"transfer batch": {
+			Caller: "owner",
+			Operation: func(c *DefaultContract) error {
+				return c.TransferBatch([]TokenTransfer{{From: "owner", To: "owner2", TokenID: "tokenID"}})
+			},
+		},
+		"burn and remint": {
+			Caller: "owner",
+			Operation: func(c *DefaultContract) error {
+				if err := c.Burn("tokenID"); err != nil {
+					return err
+				}
+				c.SetCaller("minter")
+				return c.Mint("owner", "tokenID")
+			},
+		},
+	}
+)
+
+func TestDefaultContract_SetUser(t *testing.T) {
+	for name, test := range setUserTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.SetTimestamp(1000)
+			contract.SetCaller(test.Caller)
+			assert.Equal(t, test.ExpectedError, contract.SetUser(test.TokenID, test.User, test.Expires))
+			if test.ExpectedError != nil {
+				return
+			}
+			user, err := contract.UserOf(test.TokenID)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedUser, user)
+			expires, err := contract.UserExpires(test.TokenID)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedExpires, expires)
+		})
+	}
+}
+
+func TestDefaultContract_RentalEnds(t *testing.T) {
+	for name, test := range rentalEndTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.SetTimestamp(1000)
+			contract.SetCaller("owner")
+			assert.NoError(t, contract.SetUser("tokenID", "renter", 2000))
+			assert.NoError(t, contract.SetUser("tokenID2", "renter", 2000))
+
+			contract.SetCaller(test.Caller)
+			assert.NoError(t, test.Operation(contract))
+			user, err := contract.UserOf("tokenID")
+			assert.NoError(t, err)
+			assert.Equal(t, "", user)
+			expires, err := contract.UserExpires("tokenID")
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(0), expires)
+
+			// Other rentals are unaffected.
+			user, err = contract.UserOf("tokenID2")
+			assert.NoError(t, err)
+			assert.Equal(t, "renter", user)
+		})
+	}
+}
+
+func TestDefaultContract_UserOfWithoutTimestamp(t *testing.T) {
+	contract := newBatchTestContract()
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.SetUser("tokenID", "renter", 2000))
+	// Without the invocation timestamp, expiry can not be checked.
+	user, err := contract.UserOf("tokenID")
+	assert.NoError(t, err)
+	assert.Equal(t, "", user)
+
+	contract.SetTimestamp(1000)
+	user, err = contract.UserOf("tokenID")
+	assert.NoError(t, err)
+	assert.Equal(t, "renter", user)
+}
+
+func TestDefaultContract_RentalEndPersisted(t *testing.T) {
+	for name, test := range rentalEndTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.SetCaller("owner")
+			assert.NoError(t, contract.SetUser("tokenID", "renter", 2000))
+
+			// Ending the only rental must overwrite the rentals on the heap.
+			contract.SetCaller(test.Caller)
+			assert.NoError(t, test.Operation(contract))
+			assert.JSONEq(t, `{}`, string(heapOutput(t, contract)["tokenUsers"]))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package nft
+
+import "encoding/json"
+
+// The roles understood by DefaultContract.
+const (
+	// AdminRole may grant and revoke every role.
+	AdminRole = "admin"
+	// MinterRole may mint tokens and manage collection metadata, attributes and royalties.
+	MinterRole = "minter"
+	// BurnerRole may burn any token.
+	BurnerRole = "burner"
+	// PauserRole may pause and unpause the contract.
+	PauserRole = "pauser"
+)
+
+// HasRole reports whether address holds role. The contract's configured admin implicitly holds
+// every role and its configured minter implicitly holds MinterRole.
+func (c *DefaultContract) HasRole(role, address string) (bool, error) {
+	if address == "" {
+		return false, nil
+	}
+	if address == c.ContractAdmin || (role == MinterRole && address == c.ContractMinter) {
+		return true, nil
+	}
+	if c.Roles == nil {
+		if err := c.fetchRoles(); err != nil {
+			return false, err
+		}
+	}
+	return c.Roles[role][address], nil
+}
+
+// GrantRole grants role to address. Only holders of AdminRole may grant roles.
+func (c *DefaultContract) GrantRole(role, address string) error {
+	if err := c.requireRole(AdminRole); err != nil {
+		return err
+	}
+	if c.Roles == nil {
+		if err := c.fetchRoles(); err != nil {
+			return err
+		}
+	}
+	if c.Roles[role] == nil {
+		c.Roles[role] = make(map[string]bool)
+	}
+	c.Roles[role][address] = true
+	return nil
+}
+
+// RevokeRole revokes role from address. Only holders of AdminRole may revoke roles. Roles that
+// are held implicitly through the contract's configuration cannot be revoked.
+func (c *DefaultContract) RevokeRole(role, address string) error {
+	if err := c.requireRole(AdminRole); err != nil {
+		return err
+	}
+	return c.removeRole(role, address)
+}
+
+// RenounceRole gives up a role held by the caller. address must be the caller, which guards
+// against renouncing a role by mistake.
+func (c *DefaultContract) RenounceRole(role, address string) error {
+	if c.caller == "" || c.caller != address {
+		return ErrUnauthorized
+	}
+	return c.removeRole(role, address)
+}
+
+// requireRole returns ErrUnauthorized unless the caller holds role.
+func (c *DefaultContract) requireRole(role string) error {
+	ok, err := c.HasRole(role, c.caller)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func (c *DefaultContract) removeRole(role, address string) error {
+	if c.Roles == nil {
+		if err := c.fetchRoles(); err != nil {
+			return err
+		}
+	}
+	delete(c.Roles[role], address)
+	if len(c.Roles[role]) == 0 {
+		delete(c.Roles, role)
+	}
+	return nil
+}
+
+func (c *DefaultContract) fetchRoles() error {
+	resp, err := c.GetDragonObject("roles")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.Roles = make(map[string]map[string]bool)
+		return nil
+	}
+	var m map[string]map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.Roles = m
+	return nil
+}
@@ -0,0 +1,242 @@
+package nft
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hasRoleTests = map[string]struct {
+		DCResponse    *dcResp
+		Role          string
+		Address       string
+		ExpectedRole  bool
+		ExpectedError error
+	}{
+		"granted role": {
+			DCResponse: &dcResp{
+				Response: `{"burner":{"burnerAddress":true}}`,
+			},
+			Role:         BurnerRole,
+			Address:      "burnerAddress",
+			ExpectedRole: true,
+		},
+		"other role": {
+			DCResponse: &dcResp{
+				Response: `{"burner":{"burnerAddress":true}}`,
+			},
+			Role:    PauserRole,
+			Address: "burnerAddress",
+		},
+		"not in heap": {
+			DCResponse: &dcResp{},
+			Role:       BurnerRole,
+			Address:    "burnerAddress",
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			Role:          BurnerRole,
+			Address:       "burnerAddress",
+			ExpectedError: errFailed,
+		},
+	}
+
+	roleChangeTests = map[string]struct {
+		Caller        string
+		Operation     func(c *DefaultContract) error
+		Role          string
+		Address       string
+		ExpectedRole  bool
+		ExpectedError error
+	}{
+		"granted by admin": {
+			Caller:       "admin",
+			Operation:    func(c *DefaultContract) error { return c.GrantRole(BurnerRole, "burner") },
+			Role:         BurnerRole,
+			Address:      "burner",
+			ExpectedRole: true,
+		},
+		"granted by granted admin": {
+			Caller:       "admin2",
+			Operation:    func(c *DefaultContract) error { return c.GrantRole(PauserRole, "pauser") },
+			Role:         PauserRole,
+			Address:      "pauser",
+			ExpectedRole: true,
+		},
+		"granted by minter": {
+			Caller:        "minter",
+			Operation:     func(c *DefaultContract) error { return c.GrantRole(BurnerRole, "burner") },
+			Role:          BurnerRole,
+			Address:       "burner",
+			ExpectedError: ErrUnauthorized,
+		},
+		"granted without caller": {
+			Operation:     func(c *DefaultContract) error { return c.GrantRole(BurnerRole, "burner") },
+			Role:          BurnerRole,
+			Address:       "burner",
+			ExpectedError: ErrUnauthorized,
+		},
+		"revoked by admin": {
+			Caller:    "admin",
+			Operation: func(c *DefaultContract) error { return c.RevokeRole(MinterRole, "minter2") },
+			Role:      MinterRole,
+			Address:   "minter2",
+		},
+		"revoked by holder": {
+			Caller:        "minter2",
+			Operation:     func(c *DefaultContract) error { return c.RevokeRole(MinterRole, "minter2") },
+			Role:          MinterRole,
+			Address:       "minter2",
+			ExpectedRole:  true,
+			ExpectedError: ErrUnauthorized,
+		},
+		"configured minter is not revoked": {
+			Caller:       "admin",
+			Operation:    func(c *DefaultContract) error { return c.RevokeRole(MinterRole, "minter") },
+			Role:         MinterRole,
+			Address:      "minter",
+			ExpectedRole: true,
+		},
+		"renounced by holder": {
+			Caller:    "minter2",
+			Operation: func(c *DefaultContract) error { return c.RenounceRole(MinterRole, "minter2") },
+			Role:      MinterRole,
+			Address:   "minter2",
+		},
+		"renounced for another address": {
+			Caller:        "admin",
+			Operation:     func(c *DefaultContract) error { return c.RenounceRole(MinterRole, "minter2") },
+			Role:          MinterRole,
+			Address:       "minter2",
+			ExpectedRole:  true,
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
+	roleAuthorizationTests = map[string]struct {
+		Role      string
+		Operation func(c *DefaultContract) error
+	}{
+		"minter mints": {
+			Role:      MinterRole,
+			Operation: func(c *DefaultContract) error { return c.Mint("owner", "tokenID4") },
+		},
+		"minter sets base uri": {
+			Role:      MinterRole,
+			Operation: func(c *DefaultContract) error { return c.SetBaseURI("https://example.com/") },
+		},
+		"minter sets attributes": {
+			Role:      MinterRole,
+			Operation: func(c *DefaultContract) error { return c.SetAttribute("tokenID", "level", IntAttribute(1)) },
+		},
+		"burner burns": {
+			Role:      BurnerRole,
+			Operation: func(c *DefaultContract) error { return c.Burn("tokenID") },
+		},
+		"burner burns batch": {
+			Role:      BurnerRole,
+			Operation: func(c *DefaultContract) error { return c.BurnBatch([]string{"tokenID", "tokenID3"}) },
+		},
+		"pauser pauses": {
+			Role:      PauserRole,
+			Operation: (*DefaultContract).Pause,
+		},
+	}
+)
+
+func TestDefaultContract_HasRole(t *testing.T) {
+	for name, test := range hasRoleTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			var ret *dragonchain.Response
+			if test.DCResponse.Error == nil {
+				ret = &dragonchain.Response{
+					OK:       true,
+					Status:   http.StatusOK,
+					Response: []byte(test.DCResponse.Response),
+				}
+			}
+			mockClient.On("GetSmartContractObject", "roles", "").Once().Return(ret, test.DCResponse.Error)
+			ok, err := contract.HasRole(test.Role, test.Address)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedRole, ok)
+		})
+	}
+}
+
+func TestDefaultContract_ImplicitRoles(t *testing.T) {
+	contract := NewDefaultContract("test", "TEST", &MockClient{})
+	contract.ContractAdmin = "admin"
+	contract.ContractMinter = "minter"
+	contract.Roles = make(map[string]map[string]bool)
+	for _, role := range []string{AdminRole, MinterRole, BurnerRole, PauserRole} {
+		ok, err := contract.HasRole(role, "admin")
+		assert.NoError(t, err)
+		assert.True(t, ok, role)
+	}
+	ok, err := contract.HasRole(MinterRole, "minter")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = contract.HasRole(AdminRole, "minter")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = contract.HasRole(AdminRole, "")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDefaultContract_RoleChanges(t *testing.T) {
+	for name, test := range roleChangeTests {
+		t.Run(name, func(t *testing.T) {
+			contract := NewDefaultContract("test", "TEST", &MockClient{})
+			contract.ContractAdmin = "admin"
+			contract.ContractMinter = "minter"
+			contract.Roles = map[string]map[string]bool{
+				AdminRole:  {"admin2": true},
+				MinterRole: {"minter2": true},
+			}
+			contract.SetCaller(test.Caller)
+			assert.Equal(t, test.ExpectedError, test.Operation(contract))
+			ok, err := contract.HasRole(test.Role, test.Address)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedRole, ok)
+		})
+	}
+}
+
+func TestDefaultContract_RoleAuthorization(t *testing.T) {
+	for name, test := range roleAuthorizationTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.ContractAdmin = "admin"
+			contract.SetCaller("grantee")
+			assert.Equal(t, ErrUnauthorized, test.Operation(contract))
+
+			contract.SetCaller("admin")
+			assert.NoError(t, contract.GrantRole(test.Role, "grantee"))
+			contract.SetCaller("grantee")
+			assert.NoError(t, test.Operation(contract))
+		})
+	}
+}
+
+func TestDefaultContract_RevokeRolePersisted(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetSmartContractObject", "roles", "").Return(&dragonchain.Response{
+		OK:       true,
+		Status:   http.StatusOK,
+		Response: []byte(`{"minter": {"minter2": true}}`),
+	}, nil)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.ContractAdmin = "admin"
+	contract.SetCaller("admin")
+	assert.NoError(t, contract.RevokeRole(MinterRole, "minter2"))
+	// The revocation must overwrite the grant on the heap.
+	assert.JSONEq(t, `{}`, string(heapOutput(t, contract)["roles"]))
+}
@@ -0,0 +1,151 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+// RoyaltyDenominator is the number of basis points that make up a whole sale price.
+const RoyaltyDenominator = 10000
+
+// ErrInvalidRoyalty is returned when a royalty exceeds RoyaltyDenominator basis points.
+var ErrInvalidRoyalty = errors.New("royalty exceeds sale price")
+
+// Royalty describes who is paid on secondary sales of a token and how much, in basis points
+// of the sale price.
+type Royalty struct {
+	Receiver    string `json:"receiver"`
+	BasisPoints uint64 `json:"basisPoints"`
+}
+
+// RoyaltyInfo returns the address to pay and the royalty owed when tokenID is sold for
+// salePrice, following ERC-2981. The amount is rounded down. If the token has no royalty,
+// the receiver is "" and the amount is zero.
+func (c *DefaultContract) RoyaltyInfo(tokenID string, salePrice *big.Int) (string, *big.Int, error) {
+	if salePrice.Sign() < 0 {
+		return "", BigZero, ErrInvalidAmount
+	}
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return "", BigZero, err
+	}
+	if c.TokenRoyalties == nil {
+		if err := c.fetchTokenRoyalties(); err != nil {
+			return "", BigZero, err
+		}
+	}
+	royalty, ok := c.TokenRoyalties[tokenID]
+	if !ok {
+		if c.DefaultRoyalty == nil {
+			if err := c.fetchDefaultRoyalty(); err != nil {
+				return "", BigZero, err
+			}
+		}
+		royalty = *c.DefaultRoyalty
+	}
+	if royalty.Receiver == "" {
+		return "", new(big.Int), nil
+	}
+	amount := new(big.Int).Mul(salePrice, new(big.Int).SetUint64(royalty.BasisPoints))
+	return royalty.Receiver, amount.Quo(amount, big.NewInt(RoyaltyDenominator)), nil
+}
+
+// SetDefaultRoyalty sets the royalty of every token that has no royalty of its own. Setting
+// an empty receiver removes the default royalty. Only holders of MinterRole may set royalties.
+func (c *DefaultContract) SetDefaultRoyalty(receiver string, basisPoints uint64) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	if basisPoints > RoyaltyDenominator {
+		return ErrInvalidRoyalty
+	}
+	if receiver == "" {
+		basisPoints = 0
+	}
+	c.DefaultRoyalty = &Royalty{Receiver: receiver, BasisPoints: basisPoints}
+	return nil
+}
+
+// SetTokenRoyalty sets the royalty of a single token, overriding the default royalty. Only
+// holders of MinterRole may set royalties. Setting an empty receiver removes the token's
+// royalty, like ResetTokenRoyalty. The royalty is removed when the token is burned.
+func (c *DefaultContract) SetTokenRoyalty(tokenID, receiver string, basisPoints uint64) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	if basisPoints > RoyaltyDenominator {
+		return ErrInvalidRoyalty
+	}
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return err
+	}
+	if c.TokenRoyalties == nil {
+		if err := c.fetchTokenRoyalties(); err != nil {
+			return err
+		}
+	}
+	if receiver == "" {
+		delete(c.TokenRoyalties, tokenID)
+		return nil
+	}
+	c.TokenRoyalties[tokenID] = Royalty{Receiver: receiver, BasisPoints: basisPoints}
+	return nil
+}
+
+// ResetTokenRoyalty removes the royalty of a single token so that the default royalty applies
+// to it again. Only holders of MinterRole may change royalties.
+func (c *DefaultContract) ResetTokenRoyalty(tokenID string) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	if c.TokenRoyalties == nil {
+		if err := c.fetchTokenRoyalties(); err != nil {
+			return err
+		}
+	}
+	delete(c.TokenRoyalties, tokenID)
+	return nil
+}
+
+func (c *DefaultContract) fetchDefaultRoyalty() error {
+	resp, err := c.GetDragonObject("defaultRoyalty")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.DefaultRoyalty = &Royalty{}
+		return nil
+	}
+	var r Royalty
+	if err = json.Unmarshal(resp, &r); err != nil {
+		return err
+	}
+	c.DefaultRoyalty = &r
+	return nil
+}
+
+func (c *DefaultContract) fetchTokenRoyalties() error {
+	resp, err := c.GetDragonObject("tokenRoyalties")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.TokenRoyalties = make(map[string]Royalty)
+		return nil
+	}
+	var m map[string]Royalty
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.TokenRoyalties = m
+	return nil
+}
@@ -0,0 +1,205 @@
+package nft
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	royaltyInfoTests = map[string]struct {
+		DCResponse       *dcResp
+		DefaultRoyalty   *Royalty
+		TokenRoyalties   map[string]Royalty
+		TokenID          string
+		SalePrice        *big.Int
+		ExpectedReceiver string
+		ExpectedAmount   string
+		ExpectedError    error
+	}{
+		"fetch default royalty": {
+			DCResponse: &dcResp{
+				Response: `{"receiver": "creator", "basisPoints": 250}`,
+			},
+			TokenRoyalties:   map[string]Royalty{},
+			TokenID:          "tokenID",
+			SalePrice:        big.NewInt(10000),
+			ExpectedReceiver: "creator",
+			ExpectedAmount:   "250",
+		},
+		"fetch default royalty error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			TokenRoyalties: map[string]Royalty{},
+			TokenID:        "tokenID",
+			SalePrice:      big.NewInt(10000),
+			ExpectedAmount: "0",
+			ExpectedError:  errFailed,
+		},
+		"no royalty": {
+			DCResponse:     &dcResp{},
+			TokenRoyalties: map[string]Royalty{},
+			TokenID:        "tokenID",
+			SalePrice:      big.NewInt(10000),
+			ExpectedAmount: "0",
+		},
+		"token royalty overrides default": {
+			DefaultRoyalty:   &Royalty{Receiver: "creator", BasisPoints: 250},
+			TokenRoyalties:   map[string]Royalty{"tokenID": {Receiver: "artist", BasisPoints: 1000}},
+			TokenID:          "tokenID",
+			SalePrice:        big.NewInt(10000),
+			ExpectedReceiver: "artist",
+			ExpectedAmount:   "1000",
+		},
+		"amount rounds down": {
+			DefaultRoyalty:   &Royalty{Receiver: "creator", BasisPoints: 250},
+			TokenRoyalties:   map[string]Royalty{},
+			TokenID:          "tokenID",
+			SalePrice:        big.NewInt(399),
+			ExpectedReceiver: "creator",
+			ExpectedAmount:   "9",
+		},
+		"exact for large prices": {
+			DefaultRoyalty:   &Royalty{Receiver: "creator", BasisPoints: 333},
+			TokenRoyalties:   map[string]Royalty{},
+			TokenID:          "tokenID",
+			SalePrice:        new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil),
+			ExpectedReceiver: "creator",
+			ExpectedAmount:   "33300000000000000000000000000",
+		},
+		"negative sale price": {
+			TokenID:        "tokenID",
+			SalePrice:      big.NewInt(-1),
+			ExpectedAmount: "0",
+			ExpectedError:  ErrInvalidAmount,
+		},
+		"token no exist": {
+			TokenID:        "tokenID2",
+			SalePrice:      big.NewInt(10000),
+			ExpectedAmount: "0",
+			ExpectedError:  ErrNoExist,
+		},
+	}
+
+	setTokenRoyaltyTests = map[string]struct {
+		Caller        string
+		TokenID       string
+		BasisPoints   uint64
+		ExpectedError error
+	}{
+		"royalty set": {
+			Caller:      "minter",
+			TokenID:     "tokenID",
+			BasisPoints: 500,
+		},
+		"whole price": {
+			Caller:      "minter",
+			TokenID:     "tokenID",
+			BasisPoints: RoyaltyDenominator,
+		},
+		"more than whole price": {
+			Caller:        "minter",
+			TokenID:       "tokenID",
+			BasisPoints:   RoyaltyDenominator + 1,
+			ExpectedError: ErrInvalidRoyalty,
+		},
+		"not minter": {
+			Caller:        "owner",
+			TokenID:       "tokenID",
+			BasisPoints:   500,
+			ExpectedError: ErrUnauthorized,
+		},
+		"token no exist": {
+			Caller:        "minter",
+			TokenID:       "tokenID2",
+			BasisPoints:   500,
+			ExpectedError: ErrNoExist,
+		},
+	}
+)
+
+func TestDefaultContract_RoyaltyInfo(t *testing.T) {
+	for name, test := range royaltyInfoTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner"}
+			contract.DefaultRoyalty = test.DefaultRoyalty
+			contract.TokenRoyalties = test.TokenRoyalties
+			on := test.DCResponse != nil
+			var ret *dragonchain.Response
+			if on {
+				if test.DCResponse.Error == nil {
+					ret = &dragonchain.Response{
+						OK:       true,
+						Status:   http.StatusOK,
+						Response: []byte(test.DCResponse.Response),
+					}
+				}
+				mockClient.On("GetSmartContractObject", "defaultRoyalty", "").Once().Return(ret, test.DCResponse.Error)
+			}
+			receiver, amount, err := contract.RoyaltyInfo(test.TokenID, test.SalePrice)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedReceiver, receiver)
+			assert.Equal(t, test.ExpectedAmount, amount.String())
+			if !on {
+				mockClient.AssertNotCalled(t, "GetSmartContractObject", "defaultRoyalty", "")
+			}
+		})
+	}
+}
+
+func TestDefaultContract_SetTokenRoyalty(t *testing.T) {
+	for name, test := range setTokenRoyaltyTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			emptyHeap(mockClient)
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			contract.TokenOwners = map[string]string{"tokenID": "owner"}
+			contract.TokenRoyalties = map[string]Royalty{}
+			contract.ContractMinter = "minter"
+			contract.SetCaller(test.Caller)
+			err := contract.SetTokenRoyalty(test.TokenID, "artist", test.BasisPoints)
+			assert.Equal(t, test.ExpectedError, err)
+			if test.ExpectedError != nil {
+				assert.Empty(t, contract.TokenRoyalties)
+				return
+			}
+			assert.Equal(t, Royalty{Receiver: "artist", BasisPoints: test.BasisPoints}, contract.TokenRoyalties[test.TokenID])
+		})
+	}
+}
+
+func TestDefaultContract_SetTokenRoyaltyEmptyReceiver(t *testing.T) {
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	contract.TokenOwners = map[string]string{"tokenID": "owner"}
+	contract.TokenRoyalties = map[string]Royalty{}
+	contract.ContractMinter = "minter"
+	contract.SetCaller("minter")
+	assert.NoError(t, contract.SetDefaultRoyalty("creator", 250))
+	assert.NoError(t, contract.SetTokenRoyalty("tokenID", "artist", 1000))
+
+	// An empty receiver falls back to the default royalty instead of paying no one.
+	assert.NoError(t, contract.SetTokenRoyalty("tokenID", "", 500))
+	assert.Empty(t, contract.TokenRoyalties)
+	receiver, amount, err := contract.RoyaltyInfo("tokenID", big.NewInt(10000))
+	assert.NoError(t, err)
+	assert.Equal(t, "creator", receiver)
+	assert.Equal(t, "250", amount.String())
+}
+
+func TestDefaultContract_BurnRemovesRoyalty(t *testing.T) {
+	contract := newBatchTestContract()
+	assert.NoError(t, contract.SetDefaultRoyalty("creator", 250))
+	assert.NoError(t, contract.SetTokenRoyalty("tokenID", "artist", 1000))
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Burn("tokenID"))
+	assert.Empty(t, contract.TokenRoyalties)
+	assert.Equal(t, &Royalty{Receiver: "creator", BasisPoints: 250}, contract.DefaultRoyalty)
+}
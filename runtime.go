@@ -1,10 +1,10 @@
-// +build !test
-
 package nft
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 )
@@ -31,58 +31,216 @@ type RPCHandler interface {
 	// stored on the heap, as per the usual DragonChain smart contract heap semantics.
 	//
 	// An optional error can be returned to signify that the handling of the RPC failed.
-	// In this case, nothing will be written to the heap, and the error will be logged to stderr.
+	// In this case, nothing will be written to the heap, and an ErrorResponse carrying the
+	// error's ErrorCode will be written instead.
 	HandleRPC(input []byte, contract Contract) (interface{}, error)
 }
 
+// InvocationHandlerFunc is a convenience type that allows for using a function in place of an
+// InvocationHandler. It also satisfies RPCHandler by parsing the input into an Invocation, so
+// it can be passed to NewRuntime.
+type InvocationHandlerFunc func(ctx context.Context, inv Invocation, contract Contract) (interface{}, error)
+
+// HandleInvocation exists to satisfy the InvocationHandler interface. It is a straight
+// pass-through to the underlying function.
+func (f InvocationHandlerFunc) HandleInvocation(ctx context.Context, inv Invocation, contract Contract) (interface{}, error) {
+	return f(ctx, inv, contract)
+}
+
+// HandleRPC parses input with ParseInvocation and passes the Invocation to the underlying
+// function.
+func (f InvocationHandlerFunc) HandleRPC(input []byte, contract Contract) (interface{}, error) {
+	inv, err := ParseInvocation(input)
+	if err != nil {
+		return nil, err
+	}
+	return f(context.Background(), inv, contract)
+}
+
+// InvocationHandler handles invocations of a contract. The Runtime prefers HandleInvocation
+// over HandleRPC for RPCHandlers that also implement InvocationHandler.
+type InvocationHandler interface {
+	// HandleInvocation handles an invocation like RPCHandler.HandleRPC, but receives the
+	// parsed transaction instead of the raw input. By the time it is called, contracts that
+	// support it, such as DefaultContract and MultiTokenContract, have had SetInvocation called
+	// with inv.
+	HandleInvocation(ctx context.Context, inv Invocation, contract Contract) (interface{}, error)
+}
+
+// RoleChecker is implemented by contracts that support role-based access control, such as
+// DefaultContract.
+type RoleChecker interface {
+	Caller() string
+	HasRole(role, address string) (bool, error)
+}
+
+// RequireRole returns an RPCHandler that only passes RPCs on to handler when the contract's
+// caller holds role. Otherwise ErrUnauthorized is returned. Contracts that do not implement
+// RoleChecker are always rejected.
+func RequireRole(role string, handler RPCHandler) RPCHandler {
+	return RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+		checker, ok := contract.(RoleChecker)
+		if !ok {
+			return nil, ErrUnauthorized
+		}
+		ok, err := checker.HasRole(role, checker.Caller())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrUnauthorized
+		}
+		return handler.HandleRPC(input, contract)
+	})
+}
+
+// EventEmitter is implemented by contracts that record events, such as DefaultContract.
+type EventEmitter interface {
+	Events() []Event
+}
+
 // ContractFactory creates a new Contract from some input.
 type ContractFactory interface {
 	CreateContract(name, symbol string) (Contract, error)
 }
 
+// EnvContractFactory is a ContractFactory that can be configured from an explicit environment
+// instead of the process environment. Runtime.RunWith prefers CreateContractFromEnv over
+// CreateContract for ContractFactories that implement it.
+type EnvContractFactory interface {
+	ContractFactory
+	CreateContractFromEnv(name, symbol string, env map[string]string) (Contract, error)
+}
+
 // Runtime is used to run and NFT contract.
 type Runtime struct {
 	rpcHandler      RPCHandler
 	contractFactory ContractFactory
+	middleware      []Middleware
 }
 
 // NewRuntime returns a new Runtime instance from the provided RPCHandler and ContractFactory.
-func NewRuntime(rpcHandler RPCHandler, contractFactory ContractFactory) *Runtime {
+// Every RPC is passed through the provided middleware before it reaches the RPCHandler; the
+// first middleware is the outermost, as with Chain.
+func NewRuntime(rpcHandler RPCHandler, contractFactory ContractFactory, middleware ...Middleware) *Runtime {
 	return &Runtime{
 		rpcHandler:      rpcHandler,
 		contractFactory: contractFactory,
+		middleware:      middleware,
 	}
 }
 
 // Run fetches the contract heap, creates a new contract, and
-// then uses that contract to handle the input RPC.
+// then uses that contract to handle the input RPC. It runs RunWith on the process's standard
+// streams and environment, and exits the process with the exit code of the error's class
+// (see ExitCodeOf) if it fails.
 func (r *Runtime) Run() {
-	name, symbol := os.Getenv("CONTRACT_NAME"), os.Getenv("CONTRACT_SYMBOL")
+	if err := r.RunWith(context.Background(), os.Stdin, os.Stdout, os.Stderr, environ()); err != nil {
+		os.Exit(ExitCodeOf(CodeOf(err)))
+	}
+}
+
+// RunWith creates a new contract named by the CONTRACT_NAME and CONTRACT_SYMBOL variables of
+// env, handles the invocation read from stdin, and writes the heap output to stdout.
+// ContractFactories that implement EnvContractFactory are configured from env as well.
+//
+// If anything fails, an ErrorResponse is written to stdout in place of the heap output, the
+// error is logged to stderr, and the error is returned.
+func (r *Runtime) RunWith(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, env map[string]string) error {
+	err := r.run(ctx, stdin, stdout, env)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		json.NewEncoder(stdout).Encode(NewErrorResponse(err))
+	}
+	return err
+}
+
+func (r *Runtime) run(ctx context.Context, stdin io.Reader, stdout io.Writer, env map[string]string) error {
+	name, symbol := env["CONTRACT_NAME"], env["CONTRACT_SYMBOL"]
 	if name == "" {
-		fmt.Fprintln(os.Stderr, "no name provided for contract")
-		os.Exit(1)
+		return &Error{Code: CodeInvalidConfig, Message: "no name provided for contract"}
 	}
 	if symbol == "" {
-		fmt.Fprintln(os.Stderr, "no symbol provided for contract")
-		os.Exit(1)
+		return &Error{Code: CodeInvalidConfig, Message: "no symbol provided for contract"}
+	}
+	var contract Contract
+	var err error
+	if factory, ok := r.contractFactory.(EnvContractFactory); ok {
+		contract, err = factory.CreateContractFromEnv(name, symbol, env)
+	} else {
+		contract, err = r.contractFactory.CreateContract(name, symbol)
 	}
-	contract, err := r.contractFactory.CreateContract(name, symbol)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to create contract: %s\n", err)
-		os.Exit(1)
+		return &Error{Code: CodeInvalidConfig, Message: fmt.Sprintf("failed to create contract: %s", err)}
 	}
-	b, err := ioutil.ReadAll(os.Stdin)
+	b, err := ioutil.ReadAll(stdin)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to read stdin: %s\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+	handler := r.rpcHandler
+	inv, err := ParseInvocation(b)
+	if invHandler, ok := r.rpcHandler.(InvocationHandler); ok {
+		// Middleware only knows about RPCHandlers, so InvocationHandlers are adapted for
+		// this invocation. Parse errors are returned from the adapted handler so that the
+		// middleware still sees them.
+		parseErr := err
+		handler = RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			return invHandler.HandleInvocation(ctx, inv, contract)
+		})
 	}
-	obj, err := r.rpcHandler.HandleRPC(b, contract)
+	// Plain RPCHandlers may accept input that is not a transaction, so a parse error is
+	// not fatal for them.
+	if setter, ok := contract.(invocationSetter); ok && err == nil {
+		setter.SetInvocation(inv)
+	}
+	obj, err := Chain(handler, r.middleware...).HandleRPC(b, contract)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to handle RPC: %s\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to handle RPC: %w", err)
+	}
+	if err = writeOutput(stdout, obj, contract); err != nil {
+		return fmt.Errorf("failed to JSON encode heap output: %w", err)
+	}
+	return nil
+}
+
+// invocationSetter is implemented by contracts that use the transaction that invoked them,
+// such as DefaultContract and MultiTokenContract.
+type invocationSetter interface {
+	SetInvocation(inv Invocation)
+}
+
+// writeOutput JSON encodes the object returned by an RPCHandler to w. If the contract is an
+// EventEmitter, the events it recorded are added to the output under the "events" key, as []
+// when there are none, so that the events of an earlier invocation are not left on the heap.
+// Objects keep their other keys, so their heap semantics are unchanged; any other value,
+// including null, is always wrapped as
+//   {"result": <value>, "events": [...]}
+// The output of contracts that are not an EventEmitter is written as it is.
+func writeOutput(w io.Writer, obj interface{}, contract Contract) error {
+	emitter, ok := contract.(EventEmitter)
+	if !ok {
+		return json.NewEncoder(w).Encode(obj)
+	}
+	events := emitter.Events()
+	if events == nil {
+		events = []Event{}
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil || fields == nil {
+		return json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": obj,
+			"events": events,
+		})
 	}
-	if err = json.NewEncoder(os.Stdout).Encode(obj); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to JSON encode heap output: %s\n", err)
-		os.Exit(1)
+	if fields["events"], err = json.Marshal(events); err != nil {
+		return err
 	}
+	return json.NewEncoder(w).Encode(fields)
 }
@@ -0,0 +1,364 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ invocationSetter = (*DefaultContract)(nil)
+	_ invocationSetter = (*MultiTokenContract)(nil)
+)
+
+var requireRoleTests = map[string]struct {
+	Caller         string
+	Contract       func() Contract
+	ExpectedCalled bool
+	ExpectedError  error
+}{
+	"caller holds role": {
+		Caller:         "pauser",
+		ExpectedCalled: true,
+	},
+	"admin holds role": {
+		Caller:         "admin",
+		ExpectedCalled: true,
+	},
+	"caller lacks role": {
+		Caller:        "minter",
+		ExpectedError: ErrUnauthorized,
+	},
+	"no caller": {
+		ExpectedError: ErrUnauthorized,
+	},
+	"contract without roles": {
+		Caller:        "pauser",
+		Contract:      func() Contract { return NewMultiTokenContract("test", "TEST", &MockClient{}) },
+		ExpectedError: ErrUnauthorized,
+	},
+}
+
+func TestRequireRole(t *testing.T) {
+	for name, test := range requireRoleTests {
+		t.Run(name, func(t *testing.T) {
+			var contract Contract
+			if test.Contract != nil {
+				contract = test.Contract()
+			} else {
+				c := NewDefaultContract("test", "TEST", &MockClient{})
+				c.ContractAdmin = "admin"
+				c.ContractMinter = "minter"
+				c.Roles = map[string]map[string]bool{PauserRole: {"pauser": true}}
+				c.SetCaller(test.Caller)
+				contract = c
+			}
+			called := false
+			handler := RequireRole(PauserRole, RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+				called = true
+				return string(input), nil
+			}))
+			obj, err := handler.HandleRPC([]byte("input"), contract)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedCalled, called)
+			if test.ExpectedCalled {
+				assert.Equal(t, "input", obj)
+			}
+		})
+	}
+}
+
+var writeOutputTests = map[string]struct {
+	Object         interface{}
+	Events         []Event
+	ExpectedOutput string
+}{
+	"no events": {
+		Object:         map[string]string{"name": "test"},
+		ExpectedOutput: `{"name": "test", "events": []}`,
+	},
+	"object with events": {
+		Object: map[string]string{"name": "test"},
+		Events: []Event{{Type: EventMint, Caller: "minter", To: "owner", TokenID: "tokenID"}},
+		ExpectedOutput: `{
+			"name": "test",
+			"events": [{"type": "mint", "caller": "minter", "to": "owner", "tokenId": "tokenID", "approved": false}]
+		}`,
+	},
+	"value without events": {
+		Object:         5,
+		ExpectedOutput: `{"result": 5, "events": []}`,
+	},
+	"null without events": {
+		Object:         nil,
+		ExpectedOutput: `{"result": null, "events": []}`,
+	},
+	"value with events": {
+		Object: 5,
+		Events: []Event{{Type: EventBurn, Caller: "owner", From: "owner", TokenID: "tokenID"}},
+		ExpectedOutput: `{
+			"result": 5,
+			"events": [{"type": "burn", "caller": "owner", "from": "owner", "tokenId": "tokenID", "approved": false}]
+		}`,
+	},
+}
+
+func TestWriteOutput(t *testing.T) {
+	for name, test := range writeOutputTests {
+		t.Run(name, func(t *testing.T) {
+			contract := NewDefaultContract("test", "TEST", &MockClient{})
+			contract.events = test.Events
+			var out bytes.Buffer
+			assert.NoError(t, writeOutput(&out, test.Object, contract))
+			assert.JSONEq(t, test.ExpectedOutput, out.String())
+		})
+	}
+}
+
+func TestWriteOutputNoEventEmitter(t *testing.T) {
+	contract := NewMultiTokenContract("test", "TEST", &MockClient{})
+	var out bytes.Buffer
+	assert.NoError(t, writeOutput(&out, 5, contract))
+	assert.JSONEq(t, `5`, out.String())
+}
+
+func TestInvocationHandlerFunc(t *testing.T) {
+	var got Invocation
+	handler := InvocationHandlerFunc(func(ctx context.Context, inv Invocation, contract Contract) (interface{}, error) {
+		got = inv
+		return contract.Name(), nil
+	})
+	contract := NewDefaultContract("test", "TEST", &MockClient{})
+	obj, err := handler.HandleRPC([]byte(`{"header": {"txn_id": "txnID", "invoker": "owner"}, "payload": {}}`), contract)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", obj)
+	assert.Equal(t, Invocation{TxnID: "txnID", Invoker: "owner", Payload: json.RawMessage(`{}`)}, got)
+
+	_, err = handler.HandleRPC([]byte(`{"header":`), contract)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+}
+
+// testContractFactory creates DefaultContracts on an empty heap, configured from env.
+type testContractFactory struct {
+	Err error
+}
+
+func (f *testContractFactory) CreateContract(name, symbol string) (Contract, error) {
+	return nil, errors.New("CreateContractFromEnv should be preferred")
+}
+
+func (f *testContractFactory) CreateContractFromEnv(name, symbol string, env map[string]string) (Contract, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	mockClient := &MockClient{}
+	emptyHeap(mockClient)
+	contract := NewDefaultContract(name, symbol, mockClient)
+	contract.ContractMinter = env["CONTRACT_MINTER"]
+	return contract, nil
+}
+
+var runWithTests = map[string]struct {
+	Env            map[string]string
+	FactoryError   error
+	Handler        RPCHandler
+	Input          string
+	ExpectedOutput string
+	ExpectedCode   ErrorCode
+	ExpectedExit   int
+}{
+	"mint": {
+		Input: `{"header": {"txn_id": "txnID", "timestamp": "1000", "invoker": "minter"}, "payload": {"method": "mint", "params": {"to": "owner", "tokenId": "tokenID"}}}`,
+		ExpectedOutput: `{
+			"name": "test",
+			"symbol": "TEST",
+			"minter": "minter",
+			"paused": false,
+			"totalTokens": "1",
+			"tokenOwners": {"tokenID": "owner"},
+			"ownedTokens": {"owner": ["tokenID"]},
+			"ownedTokenIndex": {"tokenID": 0},
+			"allTokens": ["tokenID"],
+			"allTokensIndex": {"tokenID": 0},
+			"mintLimit": 0,
+			"mintedCounts": {"owner": 1},
+			"mintedTokens": "1",
+			"tokenHistory": {"tokenID": [{"action": "mint", "to": "owner", "invocationId": "txnID"}]},
+			"events": [{"type": "mint", "caller": "minter", "to": "owner", "tokenId": "tokenID", "approved": false}]
+		}`,
+	},
+	"query": {
+		Input:          `{"header": {"txn_id": "txnID", "invoker": "owner"}, "payload": {"method": "totalSupply"}}`,
+		ExpectedOutput: `{"result": 0, "events": []}`,
+	},
+	"plain rpc handler": {
+		Handler: RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+			return map[string]string{"input": string(input)}, nil
+		}),
+		Input:          "not json",
+		ExpectedOutput: `{"input": "not json", "events": []}`,
+	},
+	"unauthorized": {
+		Input:          `{"header": {"txn_id": "txnID", "invoker": "owner"}, "payload": {"method": "mint", "params": {"to": "owner", "tokenId": "tokenID"}}}`,
+		ExpectedOutput: `{"error": {"code": "unauthorized", "message": "failed to handle RPC: caller is not authorized"}}`,
+		ExpectedCode:   CodeUnauthorized,
+		ExpectedExit:   ExitRejected,
+	},
+	"not found": {
+		Input:          `{"header": {"txn_id": "txnID"}, "payload": {"method": "ownerOf", "params": {"tokenId": "tokenID"}}}`,
+		ExpectedOutput: `{"error": {"code": "not_found", "message": "failed to handle RPC: resource does not exist"}}`,
+		ExpectedCode:   CodeNotFound,
+		ExpectedExit:   ExitRejected,
+	},
+	"unknown method": {
+		Input:          `{"header": {"txn_id": "txnID"}, "payload": {"method": "selfDestruct"}}`,
+		ExpectedOutput: `{"error": {"code": "unknown_method", "message": "failed to handle RPC: unknown method: \"selfDestruct\""}}`,
+		ExpectedCode:   CodeUnknownMethod,
+		ExpectedExit:   ExitInvalidRequest,
+	},
+	"invalid input": {
+		Input:          `{"header":`,
+		ExpectedOutput: `{"error": {"code": "invalid_request", "message": "failed to handle RPC: invalid request: unexpected end of JSON input"}}`,
+		ExpectedCode:   CodeInvalidRequest,
+		ExpectedExit:   ExitInvalidRequest,
+	},
+	"no name": {
+		Env:            map[string]string{"CONTRACT_SYMBOL": "TEST"},
+		ExpectedOutput: `{"error": {"code": "invalid_config", "message": "no name provided for contract"}}`,
+		ExpectedCode:   CodeInvalidConfig,
+		ExpectedExit:   ExitInvalidConfig,
+	},
+	"no symbol": {
+		Env:            map[string]string{"CONTRACT_NAME": "test"},
+		ExpectedOutput: `{"error": {"code": "invalid_config", "message": "no symbol provided for contract"}}`,
+		ExpectedCode:   CodeInvalidConfig,
+		ExpectedExit:   ExitInvalidConfig,
+	},
+	"factory error": {
+		FactoryError:   errFailed,
+		ExpectedOutput: `{"error": {"code": "invalid_config", "message": "failed to create contract: failed"}}`,
+		ExpectedCode:   CodeInvalidConfig,
+		ExpectedExit:   ExitInvalidConfig,
+	},
+	"handler error": {
+		Handler: RPCHandlerFunc(func(input []byte, contract Contract) (interface{}, error) {
+			return nil, errFailed
+		}),
+		Input:          `{}`,
+		ExpectedOutput: `{"error": {"code": "internal", "message": "failed to handle RPC: failed"}}`,
+		ExpectedCode:   CodeInternal,
+		ExpectedExit:   ExitInternal,
+	},
+}
+
+func TestRuntime_RunWith(t *testing.T) {
+	for name, test := range runWithTests {
+		t.Run(name, func(t *testing.T) {
+			env := test.Env
+			if env == nil {
+				env = map[string]string{"CONTRACT_NAME": "test", "CONTRACT_SYMBOL": "TEST", "CONTRACT_MINTER": "minter"}
+			}
+			handler := test.Handler
+			if handler == nil {
+				handler = NewDispatcher()
+			}
+			rt := NewRuntime(handler, &testContractFactory{Err: test.FactoryError})
+			var stdout, stderr bytes.Buffer
+			err := rt.RunWith(context.Background(), bytes.NewBufferString(test.Input), &stdout, &stderr, env)
+			assert.JSONEq(t, test.ExpectedOutput, stdout.String())
+			if test.ExpectedCode == "" {
+				assert.NoError(t, err)
+				assert.Empty(t, stderr.String())
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, test.ExpectedCode, CodeOf(err))
+			assert.Equal(t, test.ExpectedExit, ExitCodeOf(CodeOf(err)))
+			assert.Equal(t, err.Error()+"\n", stderr.String())
+		})
+	}
+}
+
+func TestRuntime_RunWithContractFactory(t *testing.T) {
+	var gotName, gotSymbol string
+	factory := contractFactoryFunc(func(name, symbol string) (Contract, error) {
+		gotName, gotSymbol = name, symbol
+		return NewDefaultContract(name, symbol, &MockClient{}), nil
+	})
+	rt := NewRuntime(NewDispatcher(), factory)
+	var stdout, stderr bytes.Buffer
+	env := map[string]string{"CONTRACT_NAME": "test", "CONTRACT_SYMBOL": "TEST"}
+	assert.NoError(t, rt.RunWith(context.Background(), bytes.NewBufferString(`{"method": "symbol"}`), &stdout, &stderr, env))
+	assert.Equal(t, "test", gotName)
+	assert.Equal(t, "TEST", gotSymbol)
+	assert.JSONEq(t, `{"result": "TEST", "events": []}`, stdout.String())
+}
+
+type contractFactoryFunc func(name, symbol string) (Contract, error)
+
+func (f contractFactoryFunc) CreateContract(name, symbol string) (Contract, error) {
+	return f(name, symbol)
+}
+
+var runWithInvokerTests = map[string]struct {
+	Invoker       string
+	Payload       string
+	ExpectedOwner string
+	ExpectedCode  ErrorCode
+}{
+	"owner transfers": {
+		Invoker:       "owner",
+		Payload:       `{"method": "transfer", "params": {"from": "owner", "to": "owner2", "tokenId": "tokenID"}}`,
+		ExpectedOwner: "owner2",
+	},
+	"owner burns": {
+		Invoker: "owner",
+		Payload: `{"method": "burn", "params": {"tokenId": "tokenID"}}`,
+	},
+	"stranger transfers": {
+		Invoker:       "stranger",
+		Payload:       `{"method": "transfer", "params": {"from": "owner", "to": "stranger", "tokenId": "tokenID"}}`,
+		ExpectedOwner: "owner",
+		ExpectedCode:  CodeUnauthorized,
+	},
+	"no invoker": {
+		Payload:       `{"method": "burn", "params": {"tokenId": "tokenID"}}`,
+		ExpectedOwner: "owner",
+		ExpectedCode:  CodeUnauthorized,
+	},
+}
+
+func TestRuntime_RunWithInvoker(t *testing.T) {
+	for name, test := range runWithInvokerTests {
+		t.Run(name, func(t *testing.T) {
+			var contract *DefaultContract
+			factory := contractFactoryFunc(func(name, symbol string) (Contract, error) {
+				mockClient := &MockClient{}
+				emptyHeap(mockClient)
+				contract = NewDefaultContract(name, symbol, mockClient)
+				contract.TokenOwners = map[string]string{"tokenID": "owner"}
+				contract.OwnedTokens = map[string][]string{"owner": {"tokenID"}}
+				contract.OwnedTokenIndex = map[string]uint64{"tokenID": 0}
+				contract.TokenList = []string{"tokenID"}
+				contract.TokenListIndex = map[string]uint64{"tokenID": 0}
+				contract.TotalTokens = "1"
+				return contract, nil
+			})
+			rt := NewRuntime(NewDispatcher(), factory)
+			input := `{"header": {"txn_id": "txnID", "invoker": "` + test.Invoker + `"}, "payload": ` + test.Payload + `}`
+			var stdout, stderr bytes.Buffer
+			env := map[string]string{"CONTRACT_NAME": "test", "CONTRACT_SYMBOL": "TEST"}
+			err := rt.RunWith(context.Background(), bytes.NewBufferString(input), &stdout, &stderr, env)
+			if test.ExpectedCode == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, test.ExpectedCode, CodeOf(err))
+			}
+			assert.Equal(t, test.ExpectedOwner, contract.TokenOwners["tokenID"])
+		})
+	}
+}
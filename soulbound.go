@@ -0,0 +1,76 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrSoulbound is returned when a soulbound token would be transferred or approved.
+var ErrSoulbound = errors.New("token is soulbound")
+
+// MintSoulbound mints a new token like Mint and binds it to the "to" address for good. A
+// soulbound token can never be transferred or approved; it can only be burned by its owner
+// or by a holder of AdminRole.
+func (c *DefaultContract) MintSoulbound(to, tokenID string) error {
+	if err := c.loadSoulbound(); err != nil {
+		return err
+	}
+	if err := c.Mint(to, tokenID); err != nil {
+		return err
+	}
+	c.SoulboundTokens[tokenID] = true
+	return nil
+}
+
+// IsSoulbound reports whether a token is soulbound, either because the whole collection is
+// soulbound or because the token was minted with MintSoulbound.
+func (c *DefaultContract) IsSoulbound(tokenID string) (bool, error) {
+	if _, err := c.OwnerOf(tokenID); err != nil {
+		return false, err
+	}
+	if err := c.loadSoulbound(); err != nil {
+		return false, err
+	}
+	return c.isSoulbound(tokenID), nil
+}
+
+// isSoulbound reports whether a token is soulbound. The soulbound tokens must be loaded.
+func (c *DefaultContract) isSoulbound(tokenID string) bool {
+	return c.Soulbound || c.SoulboundTokens[tokenID]
+}
+
+// authorizeSoulboundBurn returns ErrUnauthorized unless the caller is the owner of a soulbound
+// token or holds AdminRole.
+func (c *DefaultContract) authorizeSoulboundBurn(owner string) error {
+	if c.caller != "" && c.caller == owner {
+		return nil
+	}
+	return c.requireRole(AdminRole)
+}
+
+// loadSoulbound fetches the soulbound tokens from the heap if they have not been loaded yet.
+func (c *DefaultContract) loadSoulbound() error {
+	if c.SoulboundTokens == nil {
+		if err := c.fetchSoulboundTokens(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DefaultContract) fetchSoulboundTokens() error {
+	resp, err := c.GetDragonObject("soulboundTokens")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.SoulboundTokens = make(map[string]bool)
+		return nil
+	}
+	var m map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.SoulboundTokens = m
+	return nil
+}
@@ -0,0 +1,130 @@
+package nft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var soulboundTests = map[string]struct {
+	Collection    bool
+	Caller        string
+	Operation     func(c *DefaultContract) error
+	ExpectedError error
+}{
+	"transfer": {
+		Caller:        "owner",
+		Operation:     func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "badge") },
+		ExpectedError: ErrSoulbound,
+	},
+	"safe transfer": {
+		Caller:        "owner",
+		Operation:     func(c *DefaultContract) error { return c.SafeTransfer("owner", "owner2", "badge", nil) },
+		ExpectedError: ErrSoulbound,
+	},
+	"transfer batch": {
+		Caller: "owner",
+		Operation: func(c *DefaultContract) error {
+			return c.TransferBatch([]TokenTransfer{{From: "owner", To: "owner2", TokenID: "tokenID"}, {From: "owner", To: "owner2", TokenID: "badge"}})
+		},
+		ExpectedError: ErrSoulbound,
+	},
+	"transfer by operator": {
+		Caller:        "operator",
+		Operation:     func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "badge") },
+		ExpectedError: ErrSoulbound,
+	},
+	"approve": {
+		Caller:        "owner",
+		Operation:     func(c *DefaultContract) error { return c.Approve("owner", "approved", "badge") },
+		ExpectedError: ErrSoulbound,
+	},
+	"transfer other token": {
+		Caller:    "owner",
+		Operation: func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+	},
+	"burn by owner": {
+		Caller:    "owner",
+		Operation: func(c *DefaultContract) error { return c.Burn("badge") },
+	},
+	"burn by admin": {
+		Caller:    "admin",
+		Operation: func(c *DefaultContract) error { return c.Burn("badge") },
+	},
+	"burn by operator": {
+		Caller:        "operator",
+		Operation:     func(c *DefaultContract) error { return c.Burn("badge") },
+		ExpectedError: ErrUnauthorized,
+	},
+	"burn by burner": {
+		Caller:        "burner",
+		Operation:     func(c *DefaultContract) error { return c.Burn("badge") },
+		ExpectedError: ErrUnauthorized,
+	},
+	"collection transfer": {
+		Collection:    true,
+		Caller:        "owner",
+		Operation:     func(c *DefaultContract) error { return c.Transfer("owner", "owner2", "tokenID") },
+		ExpectedError: ErrSoulbound,
+	},
+	"collection approve": {
+		Collection:    true,
+		Caller:        "owner",
+		Operation:     func(c *DefaultContract) error { return c.Approve("owner", "approved", "tokenID") },
+		ExpectedError: ErrSoulbound,
+	},
+	"collection set approval for all": {
+		Collection:    true,
+		Caller:        "owner",
+		Operation:     func(c *DefaultContract) error { return c.SetApprovalForAll("owner", "operator2", true) },
+		ExpectedError: ErrSoulbound,
+	},
+	"collection revoke approval for all": {
+		Collection: true,
+		Caller:     "owner",
+		Operation:  func(c *DefaultContract) error { return c.SetApprovalForAll("owner", "operator", false) },
+	},
+	"collection burn by owner": {
+		Collection: true,
+		Caller:     "owner",
+		Operation:  func(c *DefaultContract) error { return c.Burn("tokenID") },
+	},
+}
+
+func TestDefaultContract_Soulbound(t *testing.T) {
+	for name, test := range soulboundTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.ContractAdmin = "admin"
+			contract.Roles = map[string]map[string]bool{BurnerRole: {"burner": true}}
+			contract.OperatorApprovals = map[string]map[string]bool{"owner": {"operator": true}}
+			assert.NoError(t, contract.MintSoulbound("owner", "badge"))
+			contract.Soulbound = test.Collection
+
+			contract.SetCaller(test.Caller)
+			assert.Equal(t, test.ExpectedError, test.Operation(contract))
+		})
+	}
+}
+
+func TestDefaultContract_IsSoulbound(t *testing.T) {
+	contract := newBatchTestContract()
+	assert.NoError(t, contract.MintSoulbound("owner", "badge"))
+	soulbound, err := contract.IsSoulbound("badge")
+	assert.NoError(t, err)
+	assert.True(t, soulbound)
+	soulbound, err = contract.IsSoulbound("tokenID")
+	assert.NoError(t, err)
+	assert.False(t, soulbound)
+	_, err = contract.IsSoulbound("tokenID4")
+	assert.Equal(t, ErrNoExist, err)
+
+	// Burning a soulbound token frees its id.
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Burn("badge"))
+	contract.SetCaller("minter")
+	assert.NoError(t, contract.Mint("owner", "badge"))
+	soulbound, err = contract.IsSoulbound("badge")
+	assert.NoError(t, err)
+	assert.False(t, soulbound)
+}
@@ -0,0 +1,214 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+var (
+	// ErrMaxSupplyReached is returned when minting would exceed the contract's max supply.
+	ErrMaxSupplyReached = errors.New("max supply reached")
+	// ErrMintLimitExceeded is returned when minting would give an address more tokens than
+	// the per-address mint limit allows.
+	ErrMintLimitExceeded = errors.New("mint limit exceeded")
+)
+
+// MaxSupply returns the maximum number of tokens that may ever be minted. Burned tokens still
+// count towards it. Zero means the supply is not capped.
+func (c *DefaultContract) MaxSupply() (*big.Int, error) {
+	if c.MaxTokens == "" && !c.maxTokensLoaded {
+		if err := c.fetchMaxSupply(); err != nil {
+			return BigZero, err
+		}
+	}
+	if c.MaxTokens == "" {
+		return new(big.Int), nil
+	}
+	return BigIntString(c.MaxTokens)
+}
+
+// SetMaxSupply caps the number of tokens that may ever be minted. Setting zero removes the cap.
+// The cap may not be set below the number of tokens minted so far. Only holders of AdminRole
+// may set it.
+func (c *DefaultContract) SetMaxSupply(max *big.Int) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.requireRole(AdminRole); err != nil {
+		return err
+	}
+	if max.Sign() < 0 {
+		return ErrInvalidAmount
+	}
+	minted, err := c.TotalMinted()
+	if err != nil {
+		return err
+	}
+	if max.Sign() > 0 && max.Cmp(minted) < 0 {
+		return ErrInvalidAmount
+	}
+	c.MaxTokens = max.String()
+	c.maxTokensLoaded = true
+	return nil
+}
+
+// TotalMinted returns the number of tokens that have ever been minted. Unlike TotalSupply,
+// burning a token does not lower it. Contracts that minted tokens before the count was kept
+// start counting from their total supply.
+func (c *DefaultContract) TotalMinted() (*big.Int, error) {
+	if c.MintedTokens == "" {
+		if err := c.fetchTotalMinted(); err != nil {
+			return BigZero, err
+		}
+	}
+	return BigIntString(c.MintedTokens)
+}
+
+// MintLimit returns the maximum number of tokens that may be minted to a single address. Zero
+// means there is no limit.
+func (c *DefaultContract) MintLimit() (uint64, error) {
+	if c.AddressMintLimit == nil {
+		if err := c.fetchMintLimit(); err != nil {
+			return 0, err
+		}
+	}
+	return *c.AddressMintLimit, nil
+}
+
+// SetMintLimit sets the maximum number of tokens that may be minted to a single address.
+// Setting zero removes the limit. Tokens minted before the limit was set still count towards
+// it. Only holders of AdminRole may set it.
+func (c *DefaultContract) SetMintLimit(limit uint64) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.requireRole(AdminRole); err != nil {
+		return err
+	}
+	c.AddressMintLimit = &limit
+	return nil
+}
+
+// MintedTo returns the number of tokens that have ever been minted to an address. Burning or
+// transferring a token does not lower the count.
+func (c *DefaultContract) MintedTo(address string) (uint64, error) {
+	if c.MintedCounts == nil {
+		if err := c.fetchMintedCounts(); err != nil {
+			return 0, err
+		}
+	}
+	return c.MintedCounts[address], nil
+}
+
+// loadMintCaps loads the max supply, the number of tokens minted so far, the mint limit and
+// the minted counts.
+func (c *DefaultContract) loadMintCaps() error {
+	if _, err := c.MaxSupply(); err != nil {
+		return err
+	}
+	if _, err := c.TotalMinted(); err != nil {
+		return err
+	}
+	if _, err := c.MintLimit(); err != nil {
+		return err
+	}
+	if c.MintedCounts == nil {
+		if err := c.fetchMintedCounts(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMintCaps checks that minting every token in mints keeps the number of tokens ever
+// minted within the max supply and every recipient within the mint limit. The mint caps must
+// be loaded.
+func (c *DefaultContract) checkMintCaps(mints []TokenMint) error {
+	max, err := c.MaxSupply()
+	if err != nil {
+		return err
+	}
+	if max.Sign() > 0 {
+		minted, err := c.TotalMinted()
+		if err != nil {
+			return err
+		}
+		after := minted.Add(minted, big.NewInt(int64(len(mints))))
+		if after.Cmp(max) > 0 {
+			return ErrMaxSupplyReached
+		}
+	}
+	limit := *c.AddressMintLimit
+	if limit == 0 {
+		return nil
+	}
+	minted := make(map[string]uint64, len(mints))
+	for _, m := range mints {
+		minted[m.To]++
+		if c.MintedCounts[m.To]+minted[m.To] > limit {
+			return ErrMintLimitExceeded
+		}
+	}
+	return nil
+}
+
+func (c *DefaultContract) fetchMaxSupply() error {
+	resp, err := c.GetDragonObject("maxSupply")
+	if err != nil {
+		return err
+	}
+	c.MaxTokens = string(resp)
+	c.maxTokensLoaded = true
+	return nil
+}
+
+func (c *DefaultContract) fetchTotalMinted() error {
+	resp, err := c.GetDragonObject("mintedTokens")
+	if err != nil {
+		return err
+	}
+	if len(resp) != 0 {
+		c.MintedTokens = string(resp)
+		return nil
+	}
+	totalTokens, err := c.TotalSupply()
+	if err != nil {
+		return err
+	}
+	c.MintedTokens = totalTokens.String()
+	return nil
+}
+
+func (c *DefaultContract) fetchMintLimit() error {
+	resp, err := c.GetDragonObject("mintLimit")
+	if err != nil {
+		return err
+	}
+	var limit uint64
+	if len(resp) != 0 {
+		if limit, err = strconv.ParseUint(string(resp), 10, 64); err != nil {
+			return err
+		}
+	}
+	c.AddressMintLimit = &limit
+	return nil
+}
+
+func (c *DefaultContract) fetchMintedCounts() error {
+	resp, err := c.GetDragonObject("mintedCounts")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.MintedCounts = make(map[string]uint64)
+		return nil
+	}
+	var m map[string]uint64
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.MintedCounts = m
+	return nil
+}
@@ -0,0 +1,273 @@
+package nft
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/dragonchain/dragonchain-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	maxSupplyTests = map[string]struct {
+		DCResponse    *dcResp
+		ExpectedMax   *big.Int
+		ExpectedError error
+	}{
+		"fetch max supply": {
+			DCResponse: &dcResp{
+				Response: "100",
+			},
+			ExpectedMax: big.NewInt(100),
+		},
+		"not in heap": {
+			DCResponse:  &dcResp{},
+			ExpectedMax: new(big.Int),
+		},
+		"invalid max supply": {
+			DCResponse: &dcResp{
+				Response: "many",
+			},
+			ExpectedMax:   BigZero,
+			ExpectedError: ErrInvalidBigIntString,
+		},
+		"fetch error": {
+			DCResponse: &dcResp{
+				Error: errFailed,
+			},
+			ExpectedMax:   BigZero,
+			ExpectedError: errFailed,
+		},
+	}
+
+	setMaxSupplyTests = map[string]struct {
+		Caller        string
+		Max           *big.Int
+		ExpectedMax   *big.Int
+		ExpectedError error
+	}{
+		"set by admin": {
+			Caller:      "admin",
+			Max:         big.NewInt(10),
+			ExpectedMax: big.NewInt(10),
+		},
+		"set to total supply": {
+			Caller:      "admin",
+			Max:         big.NewInt(3),
+			ExpectedMax: big.NewInt(3),
+		},
+		"removed": {
+			Caller:      "admin",
+			Max:         new(big.Int),
+			ExpectedMax: new(big.Int),
+		},
+		"below total supply": {
+			Caller:        "admin",
+			Max:           big.NewInt(2),
+			ExpectedMax:   new(big.Int),
+			ExpectedError: ErrInvalidAmount,
+		},
+		"negative": {
+			Caller:        "admin",
+			Max:           big.NewInt(-1),
+			ExpectedMax:   new(big.Int),
+			ExpectedError: ErrInvalidAmount,
+		},
+		"set by minter": {
+			Caller:        "minter",
+			Max:           big.NewInt(10),
+			ExpectedMax:   new(big.Int),
+			ExpectedError: ErrUnauthorized,
+		},
+	}
+
+	mintCapTests = map[string]struct {
+		MaxSupply      *big.Int
+		MintLimit      uint64
+		Mints          []TokenMint
+		ExpectedSupply string
+		ExpectedError  error
+	}{
+		"no caps": {
+			Mints:          []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner", TokenID: "tokenID5"}},
+			ExpectedSupply: "5",
+		},
+		"within max supply": {
+			MaxSupply:      big.NewInt(5),
+			Mints:          []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner", TokenID: "tokenID5"}},
+			ExpectedSupply: "5",
+		},
+		"max supply reached": {
+			MaxSupply:      big.NewInt(4),
+			Mints:          []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner", TokenID: "tokenID5"}},
+			ExpectedSupply: "3",
+			ExpectedError:  ErrMaxSupplyReached,
+		},
+		"within mint limit": {
+			MintLimit:      3,
+			Mints:          []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner2", TokenID: "tokenID5"}},
+			ExpectedSupply: "5",
+		},
+		"mint limit exceeded": {
+			MintLimit:      3,
+			Mints:          []TokenMint{{To: "owner", TokenID: "tokenID4"}, {To: "owner", TokenID: "tokenID5"}},
+			ExpectedSupply: "3",
+			ExpectedError:  ErrMintLimitExceeded,
+		},
+	}
+)
+
+func TestDefaultContract_MaxSupply(t *testing.T) {
+	for name, test := range maxSupplyTests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			contract := NewDefaultContract("test", "TEST", mockClient)
+			var ret *dragonchain.Response
+			if test.DCResponse.Error == nil {
+				ret = &dragonchain.Response{
+					OK:       true,
+					Status:   http.StatusOK,
+					Response: []byte(test.DCResponse.Response),
+				}
+			}
+			mockClient.On("GetSmartContractObject", "maxSupply", "").Once().Return(ret, test.DCResponse.Error)
+			max, err := contract.MaxSupply()
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedMax, max)
+		})
+	}
+}
+
+func TestDefaultContract_SetMaxSupply(t *testing.T) {
+	for name, test := range setMaxSupplyTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.ContractAdmin = "admin"
+			contract.SetCaller(test.Caller)
+			assert.Equal(t, test.ExpectedError, contract.SetMaxSupply(test.Max))
+			max, err := contract.MaxSupply()
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedMax, max)
+		})
+	}
+}
+
+func TestDefaultContract_SetMintLimit(t *testing.T) {
+	contract := newBatchTestContract()
+	contract.ContractAdmin = "admin"
+	assert.Equal(t, ErrUnauthorized, contract.SetMintLimit(1))
+	contract.SetCaller("admin")
+	assert.NoError(t, contract.SetMintLimit(1))
+	limit, err := contract.MintLimit()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), limit)
+}
+
+func TestDefaultContract_MintCaps(t *testing.T) {
+	for name, test := range mintCapTests {
+		t.Run(name, func(t *testing.T) {
+			contract := newBatchTestContract()
+			contract.ContractAdmin = "admin"
+			contract.SetCaller("admin")
+			if test.MaxSupply != nil {
+				assert.NoError(t, contract.SetMaxSupply(test.MaxSupply))
+			}
+			assert.NoError(t, contract.SetMintLimit(test.MintLimit))
+
+			contract.SetCaller("minter")
+			assert.Equal(t, test.ExpectedError, contract.MintBatch(test.Mints))
+			assert.Equal(t, test.ExpectedSupply, contract.TotalTokens)
+
+			// Single mints are capped the same way.
+			contract.TotalTokens = "3"
+			contract.MintedTokens = "3"
+			contract.MintedCounts = map[string]uint64{"owner": 2, "owner2": 1}
+			var err error
+			for _, m := range test.Mints {
+				if err = contract.Mint(m.To, m.TokenID+"single"); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, test.ExpectedError, err)
+		})
+	}
+}
+
+func TestDefaultContract_MaxSupplyAfterBurn(t *testing.T) {
+	contract := newBatchTestContract()
+	contract.ContractAdmin = "admin"
+	contract.SetCaller("admin")
+	assert.NoError(t, contract.SetMaxSupply(big.NewInt(3)))
+
+	// Burning a token does not free up supply under the cap.
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Burn("tokenID"))
+	contract.SetCaller("minter")
+	assert.Equal(t, ErrMaxSupplyReached, contract.Mint("owner", "tokenID4"))
+	assert.Equal(t, ErrMaxSupplyReached, contract.Mint("owner", "tokenID"))
+	assert.Equal(t, "2", contract.TotalTokens)
+	minted, err := contract.TotalMinted()
+	assert.NoError(t, err)
+	assert.Equal(t, "3", minted.String())
+
+	// Nor can the cap be lowered below the tokens minted so far.
+	contract.SetCaller("admin")
+	assert.Equal(t, ErrInvalidAmount, contract.SetMaxSupply(big.NewInt(2)))
+}
+
+func TestDefaultContract_TotalMinted(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetSmartContractObject", "mintedTokens", "").Return(&dragonchain.Response{Status: http.StatusNotFound}, nil)
+	mockClient.On("GetSmartContractObject", "totalTokens", "").Return(&dragonchain.Response{
+		OK:       true,
+		Status:   http.StatusOK,
+		Response: []byte("7"),
+	}, nil)
+	contract := NewDefaultContract("test", "TEST", mockClient)
+	// Without a count on the heap, the count starts from the total supply.
+	minted, err := contract.TotalMinted()
+	assert.NoError(t, err)
+	assert.Equal(t, "7", minted.String())
+}
+
+func TestDefaultContract_MintedTo(t *testing.T) {
+	contract := newBatchTestContract()
+	for address, expected := range map[string]uint64{"owner": 2, "owner2": 1, "owner3": 0} {
+		minted, err := contract.MintedTo(address)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, minted, address)
+	}
+
+	// Burning does not free up the mint limit.
+	contract.SetCaller("owner")
+	assert.NoError(t, contract.Burn("tokenID"))
+	minted, err := contract.MintedTo("owner")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), minted)
+}
+
+func TestDefaultContract_MaxSupplyBeforeMintedCount(t *testing.T) {
+	heap := memoryHeap{}
+	invoke := func(caller string, op func(c *DefaultContract) error) error {
+		contract := NewDefaultContract("test", "TEST", heap)
+		contract.ContractMinter = "minter"
+		contract.SetCaller(caller)
+		if err := op(contract); err != nil {
+			return err
+		}
+		heap.persist(t, contract)
+		return nil
+	}
+	assert.NoError(t, invoke("minter", func(c *DefaultContract) error { return c.Mint("owner", "1") }))
+	assert.NoError(t, invoke("minter", func(c *DefaultContract) error { return c.Mint("owner", "2") }))
+	// A contract that minted its tokens before the count was kept has no count on the heap.
+	delete(heap, "mintedTokens")
+	heap["maxSupply"] = []byte("3")
+
+	assert.NoError(t, invoke("owner", func(c *DefaultContract) error { return c.Burn("1") }))
+	assert.NoError(t, invoke("minter", func(c *DefaultContract) error { return c.Mint("owner", "3") }))
+	err := invoke("minter", func(c *DefaultContract) error { return c.Mint("owner", "4") })
+	assert.Equal(t, ErrMaxSupplyReached, err)
+	assert.Equal(t, []byte("3"), heap["mintedTokens"])
+}
@@ -0,0 +1,180 @@
+package nft
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrInvalidSignature is returned when a voucher is not signed by an authorized signer.
+	ErrInvalidSignature = errors.New("invalid voucher signature")
+	// ErrVoucherExpired is returned when a voucher is redeemed after it expired.
+	ErrVoucherExpired = errors.New("voucher expired")
+	// ErrVoucherRedeemed is returned when a voucher's nonce has already been spent.
+	ErrVoucherRedeemed = errors.New("voucher already redeemed")
+)
+
+// MintVoucher authorizes minting a token that has not been minted yet, so that creators can
+// list tokens without paying to mint them up front. Expires is a unix timestamp in seconds;
+// zero means the voucher never expires. Nonce must be unique among the vouchers of the
+// contract, for example a random string.
+type MintVoucher struct {
+	TokenID string `json:"tokenId"`
+	To      string `json:"to"`
+	URI     string `json:"uri,omitempty"`
+	Expires uint64 `json:"expires,omitempty"`
+	Nonce   string `json:"nonce"`
+}
+
+// SignedVoucher is a MintVoucher signed by a voucher signer. Signer is the signer's
+// hex-encoded ed25519 public key.
+type SignedVoucher struct {
+	Voucher   MintVoucher `json:"voucher"`
+	Signer    string      `json:"signer"`
+	Signature []byte      `json:"signature"`
+}
+
+// SignVoucher signs a voucher for the contract with the given name.
+func SignVoucher(contractName string, voucher MintVoucher, key ed25519.PrivateKey) (SignedVoucher, error) {
+	msg, err := voucherMessage(contractName, voucher)
+	if err != nil {
+		return SignedVoucher{}, err
+	}
+	return SignedVoucher{
+		Voucher:   voucher,
+		Signer:    hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Signature: ed25519.Sign(key, msg),
+	}, nil
+}
+
+// voucherMessage returns the message that is signed for a voucher. The contract name is part
+// of the message so that a voucher cannot be redeemed on another contract.
+func voucherMessage(contractName string, voucher MintVoucher) ([]byte, error) {
+	return json.Marshal(struct {
+		Contract string      `json:"contract"`
+		Voucher  MintVoucher `json:"voucher"`
+	}{contractName, voucher})
+}
+
+// SetVoucherSigner allows or disallows the holder of an ed25519 key to sign mint vouchers.
+// Only holders of MinterRole may manage voucher signers.
+func (c *DefaultContract) SetVoucherSigner(publicKey ed25519.PublicKey, allowed bool) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.authorizeMint(); err != nil {
+		return err
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return ErrInvalidSignature
+	}
+	if c.VoucherSigners == nil {
+		if err := c.fetchVoucherSigners(); err != nil {
+			return err
+		}
+	}
+	signer := hex.EncodeToString(publicKey)
+	if allowed {
+		c.VoucherSigners[signer] = true
+		return nil
+	}
+	delete(c.VoucherSigners, signer)
+	return nil
+}
+
+// RedeemVoucher mints the token described by a signed voucher to the voucher's recipient and
+// sets its URI. Anyone may redeem a voucher, but each voucher can only be redeemed once and
+// not after it expired at the invocation timestamp.
+func (c *DefaultContract) RedeemVoucher(signed SignedVoucher) error {
+	if err := c.whenNotPaused(); err != nil {
+		return err
+	}
+	if err := c.verifyVoucher(signed); err != nil {
+		return err
+	}
+	voucher := signed.Voucher
+	if voucher.Expires != 0 && voucher.Expires < c.timestamp {
+		return ErrVoucherExpired
+	}
+	if c.VoucherNonces == nil {
+		if err := c.fetchVoucherNonces(); err != nil {
+			return err
+		}
+	}
+	if c.VoucherNonces[voucher.Nonce] {
+		return ErrVoucherRedeemed
+	}
+	if c.TokenURIs == nil {
+		if err := c.fetchTokenURIs(); err != nil {
+			return err
+		}
+	}
+	if err := c.mint(voucher.To, voucher.TokenID); err != nil {
+		return err
+	}
+	if voucher.URI != "" {
+		c.TokenURIs[voucher.TokenID] = voucher.URI
+	}
+	c.VoucherNonces[voucher.Nonce] = true
+	return nil
+}
+
+// verifyVoucher checks that a voucher is signed by an authorized voucher signer.
+func (c *DefaultContract) verifyVoucher(signed SignedVoucher) error {
+	if c.VoucherSigners == nil {
+		if err := c.fetchVoucherSigners(); err != nil {
+			return err
+		}
+	}
+	if !c.VoucherSigners[signed.Signer] {
+		return ErrInvalidSignature
+	}
+	publicKey, err := hex.DecodeString(signed.Signer)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return ErrInvalidSignature
+	}
+	msg, err := voucherMessage(c.ContractName, signed.Voucher)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, msg, signed.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (c *DefaultContract) fetchVoucherSigners() error {
+	resp, err := c.GetDragonObject("voucherSigners")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.VoucherSigners = make(map[string]bool)
+		return nil
+	}
+	var m map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.VoucherSigners = m
+	return nil
+}
+
+func (c *DefaultContract) fetchVoucherNonces() error {
+	resp, err := c.GetDragonObject("voucherNonces")
+	if err != nil {
+		return err
+	}
+	if len(resp) == 0 {
+		c.VoucherNonces = make(map[string]bool)
+		return nil
+	}
+	var m map[string]bool
+	if err = json.Unmarshal(resp, &m); err != nil {
+		return err
+	}
+	c.VoucherNonces = m
+	return nil
+}
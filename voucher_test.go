@@ -0,0 +1,128 @@
+package nft
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var redeemVoucherTests = map[string]struct {
+	Voucher       MintVoucher
+	Tamper        func(v *SignedVoucher)
+	UnknownSigner bool
+	OtherContract bool
+	ExpectedOwner string
+	ExpectedURI   string
+	ExpectedError error
+}{
+	"redeemed": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", URI: "ipfs://tokenID4", Expires: 2000, Nonce: "nonce"},
+		ExpectedOwner: "buyer",
+		ExpectedURI:   "ipfs://tokenID4",
+	},
+	"never expires": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"},
+		ExpectedOwner: "buyer",
+	},
+	"expired": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Expires: 999, Nonce: "nonce"},
+		ExpectedError: ErrVoucherExpired,
+	},
+	"nonce spent": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "spent"},
+		ExpectedError: ErrVoucherRedeemed,
+	},
+	"recipient changed": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"},
+		Tamper:        func(v *SignedVoucher) { v.Voucher.To = "thief" },
+		ExpectedError: ErrInvalidSignature,
+	},
+	"signer changed": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"},
+		Tamper:        func(v *SignedVoucher) { v.Signer = "nonsense" },
+		ExpectedError: ErrInvalidSignature,
+	},
+	"unknown signer": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"},
+		UnknownSigner: true,
+		ExpectedError: ErrInvalidSignature,
+	},
+	"signed for other contract": {
+		Voucher:       MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"},
+		OtherContract: true,
+		ExpectedError: ErrInvalidSignature,
+	},
+	"token exists": {
+		Voucher:       MintVoucher{TokenID: "tokenID", To: "buyer", Nonce: "nonce"},
+		ExpectedOwner: "owner",
+		ExpectedURI:   "uri",
+		ExpectedError: ErrAlreadyExists,
+	},
+}
+
+func TestDefaultContract_RedeemVoucher(t *testing.T) {
+	for name, test := range redeemVoucherTests {
+		t.Run(name, func(t *testing.T) {
+			publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+			contract := newBatchTestContract()
+			if !test.UnknownSigner {
+				assert.NoError(t, contract.SetVoucherSigner(publicKey, true))
+			}
+			contract.VoucherNonces = map[string]bool{"spent": true}
+			contract.SetTimestamp(1000)
+
+			contractName := contract.Name()
+			if test.OtherContract {
+				contractName = "other"
+			}
+			signed, err := SignVoucher(contractName, test.Voucher, privateKey)
+			assert.NoError(t, err)
+			if test.Tamper != nil {
+				test.Tamper(&signed)
+			}
+
+			contract.SetCaller("anyone")
+			assert.Equal(t, test.ExpectedError, contract.RedeemVoucher(signed))
+			owner, _ := contract.OwnerOf(test.Voucher.TokenID)
+			assert.Equal(t, test.ExpectedOwner, owner)
+			if owner != "" {
+				uri, err := contract.TokenURI(test.Voucher.TokenID)
+				assert.NoError(t, err)
+				assert.Equal(t, test.ExpectedURI, uri)
+			}
+			// The nonce is only spent when the token is minted.
+			assert.Equal(t, test.ExpectedError == nil, contract.VoucherNonces["nonce"])
+		})
+	}
+}
+
+func TestDefaultContract_RedeemVoucherTwice(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	contract := newBatchTestContract()
+	assert.NoError(t, contract.SetVoucherSigner(publicKey, true))
+	signed, err := SignVoucher(contract.Name(), MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"}, privateKey)
+	assert.NoError(t, err)
+	assert.NoError(t, contract.RedeemVoucher(signed))
+
+	contract.SetCaller("buyer")
+	assert.NoError(t, contract.Burn("tokenID4"))
+	assert.Equal(t, ErrVoucherRedeemed, contract.RedeemVoucher(signed))
+}
+
+func TestDefaultContract_SetVoucherSigner(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	contract := newBatchTestContract()
+	contract.SetCaller("owner")
+	assert.Equal(t, ErrUnauthorized, contract.SetVoucherSigner(publicKey, true))
+	contract.SetCaller("minter")
+	assert.Equal(t, ErrInvalidSignature, contract.SetVoucherSigner(publicKey[:4], true))
+	assert.NoError(t, contract.SetVoucherSigner(publicKey, true))
+	assert.NoError(t, contract.SetVoucherSigner(publicKey, false))
+	assert.JSONEq(t, `{}`, string(heapOutput(t, contract)["voucherSigners"]))
+
+	signed, err := SignVoucher(contract.Name(), MintVoucher{TokenID: "tokenID4", To: "buyer", Nonce: "nonce"}, privateKey)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrInvalidSignature, contract.RedeemVoucher(signed))
+}